@@ -2,21 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	httpdelivery "workout-tracker/internal/delivery/http"
 	"workout-tracker/internal/delivery/http/middleware"
+	"workout-tracker/internal/domain"
 	"workout-tracker/internal/infrastructure"
 	"workout-tracker/internal/infrastructure/auth"
 	"workout-tracker/internal/infrastructure/migration"
+	"workout-tracker/internal/infrastructure/notifier"
 	"workout-tracker/internal/infrastructure/repository"
 	"workout-tracker/internal/infrastructure/seeder"
 	"workout-tracker/internal/platform/logger"
+	"workout-tracker/internal/platform/worker"
 	"workout-tracker/internal/usecase"
 )
 
@@ -50,17 +55,93 @@ func main() {
 	exerciseRepo := repository.NewPostgresExerciseRepository(db)
 	scheduledRepo := repository.NewPostgresScheduledWorkoutRepository(db)
 	planChecker := repository.NewPostgresWorkoutPlanChecker(db)
+	sessionRepo := repository.NewPostgresWorkoutSessionRepository(db)
+	seriesRepo := repository.NewPostgresScheduleSeriesRepository(db)
+	calendarRepo := repository.NewPostgresCalendarRepository(db)
+	reminderRepo := repository.NewPostgresReminderRepository(db)
+	reportRepo := repository.NewPostgresReportRepository(db)
+	recordRepo := repository.NewPostgresRecordRepository(db)
+	rollupRepo := repository.NewPostgresRollupRepository(db)
+	digestRepo := repository.NewPostgresDigestRepository(db)
+	mailOutboxRepo := repository.NewPostgresMailOutboxRepository(db)
 
 	jwtSvc := auth.NewJWTService(cfg.JWTSecret)
 	userUC := usecase.NewUserUsecase(userRepo, jwtSvc)
 	workoutUC := usecase.NewWorkoutUsecase(workoutRepo)
 	exerciseUC := usecase.NewExerciseUsecase(exerciseRepo)
-	scheduledUC := usecase.NewScheduledWorkoutUsecase(scheduledRepo, planChecker)
-	handler := httpdelivery.NewHandler(appLogger, userUC, workoutUC, exerciseUC, scheduledUC)
+	scheduledUC := usecase.NewScheduledWorkoutUsecase(scheduledRepo, planChecker, sessionRepo, userRepo, seriesRepo)
+	sessionUC := usecase.NewSessionUsecase(sessionRepo, workoutRepo, exerciseRepo, recordRepo, rollupRepo, workoutUC, appLogger)
+	calendarUC := usecase.NewCalendarUsecase(calendarRepo, scheduledUC)
+	reportUC := usecase.NewReportUsecase(reportRepo, userRepo, exerciseRepo, scheduledUC)
+	recordUC := usecase.NewRecordUsecase(recordRepo)
+	handler := httpdelivery.NewHandler(appLogger, userUC, workoutUC, exerciseUC, scheduledUC, sessionUC, calendarUC, reportUC, recordUC)
 	router := httpdelivery.NewRouter(handler, jwtSvc)
 	h := middleware.RequestIDMiddleware(middleware.LoggingMiddleware(appLogger)(middleware.RecoveryMiddleware(appLogger)(router)))
 	_, _, _ = exerciseRepo, workoutUC, router
 
+	var notifiers []domain.Notifier
+	if cfg.ReminderWebhookURL != "" {
+		notifiers = append(notifiers, notifier.NewWebhookNotifier(cfg.ReminderWebhookURL, cfg.ReminderWebhookSecret))
+	}
+	var smtpNotifier *notifier.SMTPNotifier
+	if cfg.SMTPHost != "" {
+		smtpNotifier, err = notifier.NewSMTPNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifiers = append(notifiers, smtpNotifier)
+	}
+	reminderUC := usecase.NewReminderUsecase(reminderRepo, notifiers...)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if len(notifiers) > 0 {
+		go worker.Every(jobsCtx, appLogger, "reminders", cfg.ReminderInterval, func(ctx context.Context) error {
+			// Recurring occurrences only exist once materialized, which
+			// listings do lazily; extend them for users who do not look.
+			_, staleErr := scheduledUC.MaterializeStale(ctx, time.Now())
+			sent, err := reminderUC.SendDue(ctx, time.Now())
+			if sent > 0 {
+				appLogger.Info("reminders_sent", "count", sent)
+			}
+			return errors.Join(staleErr, err)
+		})
+	}
+
+	go worker.Every(jobsCtx, appLogger, "mark_missed", cfg.MissedInterval, func(ctx context.Context) error {
+		// Occurrences nobody listed are stored first so they can be missed too.
+		_, staleErr := scheduledUC.MaterializeStale(ctx, time.Now())
+		n, err := scheduledUC.MarkMissed(ctx, time.Now(), cfg.MissedGrace)
+		if n > 0 {
+			appLogger.Info("schedules_marked_missed", "count", n)
+		}
+		return errors.Join(staleErr, err)
+	})
+
+	if smtpNotifier != nil {
+		digestRenderer, err := notifier.NewDigestRenderer()
+		if err != nil {
+			log.Fatal(err)
+		}
+		digestUC := usecase.NewDigestUsecase(digestRepo, mailOutboxRepo, reportRepo, recordRepo, scheduledUC, digestRenderer)
+		mailUC := usecase.NewMailUsecase(mailOutboxRepo, smtpNotifier)
+
+		go worker.Every(jobsCtx, appLogger, "weekly_digest", cfg.DigestInterval, func(ctx context.Context) error {
+			n, err := digestUC.EnqueueDue(ctx, time.Now())
+			if n > 0 {
+				appLogger.Info("digests_queued", "count", n)
+			}
+			return err
+		})
+		go worker.Every(jobsCtx, appLogger, "mail_outbox", cfg.MailInterval, func(ctx context.Context) error {
+			n, err := mailUC.SendDue(ctx, time.Now())
+			if n > 0 {
+				appLogger.Info("mail_sent", "count", n)
+			}
+			return err
+		})
+	}
+
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
 		Handler:           h,
@@ -77,6 +158,7 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
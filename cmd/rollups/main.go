@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"workout-tracker/internal/infrastructure"
+	"workout-tracker/internal/infrastructure/migration"
+	"workout-tracker/internal/infrastructure/repository"
+)
+
+// Rebuilds the report rollups from the recorded sessions, for every user or
+// for the one given with -user.
+func main() {
+	userID := flag.String("user", "", "rebuild only this user's rollups")
+	flag.Parse()
+
+	cfg, err := infrastructure.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := infrastructure.NewPostgresDB(&cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := migration.RunMigrations(db); err != nil {
+		log.Fatal(err)
+	}
+
+	rollupRepo := repository.NewPostgresRollupRepository(db)
+	ctx := context.Background()
+
+	if *userID != "" {
+		if err := rollupRepo.RebuildUser(ctx, *userID); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Rebuilt report rollups for user %s", *userID)
+		return
+	}
+
+	n, err := rollupRepo.RebuildAll(ctx)
+	if err != nil {
+		log.Fatalf("rebuilt %d users before failing: %v", n, err)
+	}
+	log.Printf("Rebuilt report rollups for %d users", n)
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/pkg/response"
+)
+
+type CalendarFeedResponse struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+func (h *Handler) CalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		token, err := h.calendarUsecase.CreateFeed(r.Context(), userID)
+		if err != nil {
+			httperr.WriteError(w, r, h.logger, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, CalendarFeedResponse{URL: feedURL(r, token), Token: token})
+	case http.MethodDelete:
+		if err := h.calendarUsecase.RevokeFeed(r.Context(), userID); err != nil {
+			httperr.WriteError(w, r, h.logger, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]string{"message": "revoked"})
+	default:
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+	}
+}
+
+func (h *Handler) CalendarView(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	filter, err := parseScheduleFilter(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	view, err := h.calendarUsecase.GetCalendarView(r.Context(), userID, filter.From, filter.To, r.URL.Query().Get("granularity"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToCalendarViewDTO(*view))
+}
+
+// CalendarICS serves a feed by its secret token. It is deliberately outside
+// the JWT middleware so calendar clients can subscribe to the URL.
+func (h *Handler) CalendarICS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	parts := pathSegments(r, "/calendar/")
+	if len(parts) != 1 || !strings.HasSuffix(parts[0], ".ics") {
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+		return
+	}
+
+	body, err := h.calendarUsecase.FeedICS(r.Context(), strings.TrimSuffix(parts[0], ".ics"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func feedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + "/calendar/" + token + ".ics"
+}
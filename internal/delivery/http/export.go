@@ -0,0 +1,41 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/platform/export"
+)
+
+// exportFormat reads the format query parameter of exportable listings. An
+// empty result means the usual JSON response.
+func exportFormat(r *http.Request) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch {
+	case format == "" || format == "json":
+		return "", nil
+	case export.IsValidFormat(format):
+		return format, nil
+	default:
+		return "", domain.ErrInvalidInput
+	}
+}
+
+// writeExport renders doc as an attachment named name with the format's
+// extension.
+func (h *Handler) writeExport(w http.ResponseWriter, r *http.Request, format, name string, doc export.Document) {
+	var b bytes.Buffer
+	if err := export.Write(&b, format, doc); err != nil {
+		httperr.WriteError(w, r, h.logger, fmt.Errorf("render %s export: %w", format, err))
+		return
+	}
+
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(b.Bytes())
+}
@@ -31,12 +31,74 @@ type CreateWorkoutRequest struct {
 	Exercises []CreateWorkoutExerciseInput `json:"exercises"`
 }
 
+type CloneWorkoutRequest struct {
+	Name        string   `json:"name"`
+	WeightScale *float64 `json:"weight_scale"`
+}
+
 type CreateWorkoutExerciseInput struct {
-	ExerciseID string  `json:"exercise_id"`
-	Sets       int     `json:"sets"`
-	Reps       int     `json:"reps"`
-	Weight     float64 `json:"weight"`
-	OrderIndex int     `json:"order_index"`
+	ExerciseID string              `json:"exercise_id"`
+	Sets       int                 `json:"sets"`
+	Reps       int                 `json:"reps"`
+	Weight     float64             `json:"weight"`
+	OrderIndex int                 `json:"order_index"`
+	Group      *ExerciseGroupInput `json:"group"`
+	// TrainingMax and SetPrescriptions are optional; with a per-set list,
+	// sets and reps may be left out.
+	TrainingMax      *float64               `json:"training_max"`
+	SetPrescriptions []SetPrescriptionInput `json:"set_prescriptions"`
+}
+
+type SetPrescriptionInput struct {
+	Type    string   `json:"type"`
+	Reps    int      `json:"reps"`
+	RepsMax int      `json:"reps_max"`
+	Weight  *float64 `json:"weight"`
+	Percent *float64 `json:"percent"`
+	RPE     *float64 `json:"rpe"`
+}
+
+// setPrescriptionsFromInput converts a per-set list; sets without a type are
+// working sets.
+func setPrescriptionsFromInput(ins []SetPrescriptionInput) []domain.SetPrescription {
+	if len(ins) == 0 {
+		return nil
+	}
+	out := make([]domain.SetPrescription, 0, len(ins))
+	for _, in := range ins {
+		typ := strings.TrimSpace(in.Type)
+		if typ == "" {
+			typ = domain.SetTypeWorking
+		}
+		out = append(out, domain.SetPrescription{
+			Type:    typ,
+			Reps:    in.Reps,
+			RepsMax: in.RepsMax,
+			Weight:  in.Weight,
+			Percent: in.Percent,
+			RPE:     in.RPE,
+		})
+	}
+	return out
+}
+
+type ExerciseGroupInput struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Rounds      int    `json:"rounds"`
+	RestSeconds int    `json:"rest_seconds"`
+}
+
+func (in *ExerciseGroupInput) toDomain() *domain.ExerciseGroup {
+	if in == nil {
+		return nil
+	}
+	return &domain.ExerciseGroup{
+		ID:          strings.TrimSpace(in.ID),
+		Type:        strings.TrimSpace(in.Type),
+		Rounds:      in.Rounds,
+		RestSeconds: in.RestSeconds,
+	}
 }
 
 type UpdateWorkoutRequest struct {
@@ -46,8 +108,18 @@ type UpdateWorkoutRequest struct {
 }
 
 type ScheduleWorkoutRequest struct {
-	WorkoutPlanID string `json:"workout_plan_id"`
-	ScheduledDate string `json:"scheduled_date"`
+	WorkoutPlanID string   `json:"workout_plan_id"`
+	ScheduledAt   string   `json:"scheduled_at"`
+	ScheduledDate string   `json:"scheduled_date"`
+	Timezone      string   `json:"timezone"`
+	RRule         string   `json:"rrule"`
+	ExDates       []string `json:"exdates"`
+}
+
+type UpdatePreferencesRequest struct {
+	Timezone            *string `json:"timezone"`
+	ReminderLeadMinutes *int    `json:"reminder_lead_minutes"`
+	WeeklyDigest        *bool   `json:"weekly_digest"`
 }
 
 type Handler struct {
@@ -56,10 +128,14 @@ type Handler struct {
 	workoutUsecase          *usecase.WorkoutUsecase
 	exerciseUsecase         *usecase.ExerciseUsecase
 	scheduledWorkoutUsecase *usecase.ScheduledWorkoutUsecase
+	sessionUsecase          *usecase.SessionUsecase
+	calendarUsecase         *usecase.CalendarUsecase
+	reportUsecase           *usecase.ReportUsecase
+	recordUsecase           *usecase.RecordUsecase
 }
 
-func NewHandler(logger *slog.Logger, userUC *usecase.UserUsecase, workoutUC *usecase.WorkoutUsecase, exerciseUC *usecase.ExerciseUsecase, scheduledUC *usecase.ScheduledWorkoutUsecase) *Handler {
-	return &Handler{logger: logger, userUsecase: userUC, workoutUsecase: workoutUC, exerciseUsecase: exerciseUC, scheduledWorkoutUsecase: scheduledUC}
+func NewHandler(logger *slog.Logger, userUC *usecase.UserUsecase, workoutUC *usecase.WorkoutUsecase, exerciseUC *usecase.ExerciseUsecase, scheduledUC *usecase.ScheduledWorkoutUsecase, sessionUC *usecase.SessionUsecase, calendarUC *usecase.CalendarUsecase, reportUC *usecase.ReportUsecase, recordUC *usecase.RecordUsecase) *Handler {
+	return &Handler{logger: logger, userUsecase: userUC, workoutUsecase: workoutUC, exerciseUsecase: exerciseUC, scheduledWorkoutUsecase: scheduledUC, sessionUsecase: sessionUC, calendarUsecase: calendarUC, reportUsecase: reportUC, recordUsecase: recordUC}
 }
 
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
@@ -141,27 +217,49 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
-		return
-	}
-
 	userID, ok := GetUserIDFromContext(r.Context())
 	if !ok {
 		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
 		return
 	}
 
-	user, err := h.userUsecase.GetByID(r.Context(), userID)
+	var (
+		user *domain.User
+		err  error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		user, err = h.userUsecase.GetByID(r.Context(), userID)
+	case http.MethodPatch:
+		var req UpdatePreferencesRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+			return
+		}
+
+		user, err = h.userUsecase.UpdatePreferences(r.Context(), userID, domain.UserPreferencesUpdate{
+			Timezone:            req.Timezone,
+			ReminderLeadMinutes: req.ReminderLeadMinutes,
+			WeeklyDigest:        req.WeeklyDigest,
+		})
+	default:
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
 	if err != nil {
 		httperr.WriteError(w, r, h.logger, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, map[string]string{
-		"id":    user.ID,
-		"name":  user.Name,
-		"email": user.Email,
+	response.JSON(w, http.StatusOK, map[string]interface{}{
+		"id":                    user.ID,
+		"name":                  user.Name,
+		"email":                 user.Email,
+		"timezone":              user.Timezone,
+		"reminder_lead_minutes": user.ReminderLeadMinutes,
+		"weekly_digest":         user.WeeklyDigest,
 	})
 }
 
@@ -192,12 +290,24 @@ func (h *Handler) WorkoutByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	planID := strings.TrimPrefix(r.URL.Path, "/api/workouts/")
-	planID = strings.TrimSpace(planID)
-	if planID == "" || strings.Contains(planID, "/") {
+	parts := pathSegments(r, "/api/workouts/")
+	if len(parts) == 2 && parts[1] == "clone" {
+		if r.Method != http.MethodPost {
+			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+			return
+		}
+		h.CloneWorkout(w, r, userID, parts[0])
+		return
+	}
+	if len(parts) > 1 {
+		h.planVersions(w, r, userID, parts)
+		return
+	}
+	if len(parts) == 0 {
 		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
 		return
 	}
+	planID := parts[0]
 
 	switch r.Method {
 	case http.MethodGet:
@@ -242,30 +352,34 @@ func (h *Handler) CreateWorkout(w http.ResponseWriter, r *http.Request, userID s
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
-		if in.Sets <= 0 {
+		if in.Sets <= 0 && len(in.SetPrescriptions) == 0 {
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
-		if in.Reps <= 0 {
+		if in.Reps <= 0 && len(in.SetPrescriptions) == 0 {
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
 
 		exercises = append(exercises, domain.WorkoutPlanExercise{
-			ExerciseID: in.ExerciseID,
-			Sets:       in.Sets,
-			Reps:       in.Reps,
-			Weight:     in.Weight,
-			OrderIndex: in.OrderIndex,
+			ExerciseID:       in.ExerciseID,
+			Sets:             in.Sets,
+			Reps:             in.Reps,
+			Weight:           in.Weight,
+			OrderIndex:       in.OrderIndex,
+			Group:            in.Group.toDomain(),
+			TrainingMax:      in.TrainingMax,
+			SetPrescriptions: setPrescriptionsFromInput(in.SetPrescriptions),
 		})
 	}
 
-	if err := h.workoutUsecase.CreatePlan(r.Context(), userID, req.Name, req.Notes, exercises); err != nil {
+	plan, err := h.workoutUsecase.CreatePlan(r.Context(), userID, req.Name, req.Notes, exercises)
+	if err != nil {
 		httperr.WriteError(w, r, h.logger, err)
 		return
 	}
 
-	response.JSON(w, http.StatusCreated, map[string]string{"message": "workout created"})
+	response.JSON(w, http.StatusCreated, map[string]string{"message": "workout created", "id": plan.ID})
 }
 
 func (h *Handler) ListWorkouts(w http.ResponseWriter, r *http.Request, userID string) {
@@ -324,13 +438,33 @@ func (h *Handler) ListWorkouts(w http.ResponseWriter, r *http.Request, userID st
 }
 
 func (h *Handler) GetWorkoutByID(w http.ResponseWriter, r *http.Request, userID string, planID string) {
-	plan, err := h.workoutUsecase.GetPlanByID(r.Context(), userID, planID)
+	plan, err := h.workoutUsecase.GetPlanDetail(r.Context(), userID, planID)
 	if err != nil {
 		httperr.WriteError(w, r, h.logger, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, plan)
+	response.JSON(w, http.StatusOK, httperr.ToWorkoutPlanDetailDTO(*plan))
+}
+
+func (h *Handler) CloneWorkout(w http.ResponseWriter, r *http.Request, userID string, planID string) {
+	var req CloneWorkoutRequest
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	plan, err := h.workoutUsecase.ClonePlan(r.Context(), userID, planID, req.Name, req.WeightScale)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, map[string]string{"message": "workout cloned", "id": plan.ID})
 }
 
 func (h *Handler) DeleteWorkout(w http.ResponseWriter, r *http.Request, userID string, planID string) {
@@ -369,21 +503,24 @@ func (h *Handler) UpdateWorkout(w http.ResponseWriter, r *http.Request, userID s
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
-		if in.Sets <= 0 {
+		if in.Sets <= 0 && len(in.SetPrescriptions) == 0 {
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
-		if in.Reps <= 0 {
+		if in.Reps <= 0 && len(in.SetPrescriptions) == 0 {
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
 
 		exercises = append(exercises, domain.WorkoutPlanExercise{
-			ExerciseID: in.ExerciseID,
-			Sets:       in.Sets,
-			Reps:       in.Reps,
-			Weight:     in.Weight,
-			OrderIndex: in.OrderIndex,
+			ExerciseID:       in.ExerciseID,
+			Sets:             in.Sets,
+			Reps:             in.Reps,
+			Weight:           in.Weight,
+			OrderIndex:       in.OrderIndex,
+			Group:            in.Group.toDomain(),
+			TrainingMax:      in.TrainingMax,
+			SetPrescriptions: setPrescriptionsFromInput(in.SetPrescriptions),
 		})
 	}
 
@@ -428,25 +565,45 @@ func (h *Handler) ScheduledWorkouts(w http.ResponseWriter, r *http.Request) {
 		}
 
 		req.WorkoutPlanID = strings.TrimSpace(req.WorkoutPlanID)
-		req.ScheduledDate = strings.TrimSpace(req.ScheduledDate)
-		if req.WorkoutPlanID == "" || req.ScheduledDate == "" {
+		if req.WorkoutPlanID == "" {
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
 
-		d, err := time.Parse("2006-01-02", req.ScheduledDate)
+		in := domain.ScheduleInput{WorkoutPlanID: req.WorkoutPlanID, Timezone: req.Timezone, RRule: strings.TrimSpace(req.RRule)}
+		var err error
+		in.ScheduledAt, in.AllDay, err = parseScheduleTime(req.ScheduledAt, req.ScheduledDate)
 		if err != nil {
+			httperr.WriteError(w, r, h.logger, err)
+			return
+		}
+		if in.ExDates, err = parseDates(req.ExDates); err != nil {
+			httperr.WriteError(w, r, h.logger, err)
+			return
+		}
+
+		if in.RRule != "" {
+			s, err := h.scheduledWorkoutUsecase.ScheduleSeries(r.Context(), userID, in)
+			if err != nil {
+				httperr.WriteError(w, r, h.logger, err)
+				return
+			}
+
+			response.JSON(w, http.StatusCreated, map[string]string{"message": "scheduled", "series_id": s.ID})
+			return
+		}
+		if len(in.ExDates) > 0 {
 			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
 			return
 		}
 
-		err = h.scheduledWorkoutUsecase.ScheduleWorkout(r.Context(), userID, req.WorkoutPlanID, d)
+		sw, err := h.scheduledWorkoutUsecase.ScheduleWorkout(r.Context(), userID, in)
 		if err != nil {
 			httperr.WriteError(w, r, h.logger, err)
 			return
 		}
 
-		response.JSON(w, http.StatusCreated, map[string]string{"message": "scheduled"})
+		response.JSON(w, http.StatusCreated, map[string]string{"message": "scheduled", "id": sw.ID})
 		return
 
 	case http.MethodGet:
@@ -470,7 +627,6 @@ func (h *Handler) ScheduledWorkouts(w http.ResponseWriter, r *http.Request) {
 			}
 			limit = v
 		}
-		dateParam := strings.TrimSpace(r.URL.Query().Get("date"))
 
 		if r.URL.Query().Has("page") {
 			if page < 1 {
@@ -486,15 +642,10 @@ func (h *Handler) ScheduledWorkouts(w http.ResponseWriter, r *http.Request) {
 		}
 
 		p := domain.NewPagination(page, limit)
-		var filter domain.ScheduledWorkoutFilter
-		if dateParam != "" {
-			d, err := time.Parse("2006-01-02", dateParam)
-			if err != nil {
-				httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
-				return
-			}
-			d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
-			filter.Date = &d
+		filter, err := parseScheduleFilter(r)
+		if err != nil {
+			httperr.WriteError(w, r, h.logger, err)
+			return
 		}
 
 		res, err := h.scheduledWorkoutUsecase.GetSchedules(r.Context(), userID, p, filter)
@@ -519,25 +670,7 @@ func (h *Handler) ScheduledWorkouts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) DeleteScheduledWorkout(w http.ResponseWriter, r *http.Request) {
-	userID, ok := GetUserIDFromContext(r.Context())
-	if !ok {
-		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
-		return
-	}
-
-	if r.Method != http.MethodDelete {
-		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
-		return
-	}
-
-	id := strings.TrimPrefix(r.URL.Path, "/api/workouts/schedule/")
-	id = strings.TrimSpace(id)
-	if id == "" || strings.Contains(id, "/") {
-		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
-		return
-	}
-
+func (h *Handler) DeleteScheduledWorkout(w http.ResponseWriter, r *http.Request, userID string, id string) {
 	if err := h.scheduledWorkoutUsecase.DeleteSchedule(r.Context(), id, userID); err != nil {
 		httperr.WriteError(w, r, h.logger, err)
 		return
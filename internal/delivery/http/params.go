@@ -0,0 +1,113 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+func parsePagination(r *http.Request) (domain.Pagination, error) {
+	q := r.URL.Query()
+
+	page := 0
+	limit := 0
+	if s := q.Get("page"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return domain.Pagination{}, domain.ErrInvalidInput
+		}
+		page = v
+	}
+	if s := q.Get("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return domain.Pagination{}, domain.ErrInvalidInput
+		}
+		limit = v
+	}
+
+	if q.Has("page") && page < 1 {
+		return domain.Pagination{}, domain.ErrInvalidInput
+	}
+	if q.Has("limit") && limit < 1 {
+		return domain.Pagination{}, domain.ErrInvalidInput
+	}
+
+	return domain.NewPagination(page, limit), nil
+}
+
+func pathSegments(r *http.Request, prefix string) []string {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	if rest == "" {
+		return nil
+	}
+
+	parts := strings.Split(rest, "/")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if parts[i] == "" {
+			return nil
+		}
+	}
+	return parts
+}
+
+// parseDateRange reads the optional from and to query parameters as
+// YYYY-MM-DD dates.
+func parseDateRange(r *http.Request) (*time.Time, *time.Time, error) {
+	q := r.URL.Query()
+
+	var from, to *time.Time
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"from", &from},
+		{"to", &to},
+	} {
+		v := strings.TrimSpace(q.Get(p.name))
+		if v == "" {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, nil, domain.ErrInvalidInput
+		}
+		*p.dst = &d
+	}
+
+	return from, to, nil
+}
+
+// parseScheduleFilter reads the date, from, to, status and sort query
+// parameters of schedule listings. Dates use YYYY-MM-DD.
+func parseScheduleFilter(r *http.Request) (domain.ScheduledWorkoutFilter, error) {
+	q := r.URL.Query()
+
+	var filter domain.ScheduledWorkoutFilter
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"date", &filter.Date},
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		v := strings.TrimSpace(q.Get(p.name))
+		if v == "" {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return domain.ScheduledWorkoutFilter{}, domain.ErrInvalidInput
+		}
+		*p.dst = &d
+	}
+
+	filter.Status = strings.TrimSpace(q.Get("status"))
+	filter.Sort = strings.TrimSpace(q.Get("sort"))
+	return filter, nil
+}
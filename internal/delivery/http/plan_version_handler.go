@@ -0,0 +1,109 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/pkg/response"
+)
+
+// planVersions serves /api/workouts/{id}/versions and the routes below it.
+func (h *Handler) planVersions(w http.ResponseWriter, r *http.Request, userID string, parts []string) {
+	if parts[1] != "versions" || len(parts) > 4 {
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+		return
+	}
+	planID := parts[0]
+
+	switch {
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		h.ListPlanVersions(w, r, userID, planID)
+	case len(parts) == 3 && parts[2] == "diff" && r.Method == http.MethodGet:
+		h.DiffPlanVersions(w, r, userID, planID)
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		h.GetPlanVersion(w, r, userID, planID, parts[2])
+	case len(parts) == 4 && parts[3] == "restore" && r.Method == http.MethodPost:
+		h.RestorePlanVersion(w, r, userID, planID, parts[2])
+	default:
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+	}
+}
+
+func (h *Handler) ListPlanVersions(w http.ResponseWriter, r *http.Request, userID string, planID string) {
+	p, err := parsePagination(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	res, err := h.workoutUsecase.ListPlanVersions(r.Context(), userID, planID, p)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	data := make([]httperr.WorkoutPlanVersionDTO, 0, len(res.Data))
+	for _, v := range res.Data {
+		data = append(data, httperr.ToWorkoutPlanVersionDTO(v))
+	}
+
+	response.JSON(w, http.StatusOK, httperr.PaginatedResponse[httperr.WorkoutPlanVersionDTO]{
+		Data: data,
+		Meta: httperr.PaginationMeta{Total: res.Total, Page: res.Page, Limit: res.Limit, TotalPages: res.TotalPages},
+	})
+}
+
+func (h *Handler) GetPlanVersion(w http.ResponseWriter, r *http.Request, userID string, planID string, versionStr string) {
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+		return
+	}
+
+	v, err := h.workoutUsecase.GetPlanVersion(r.Context(), userID, planID, version)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToWorkoutPlanVersionDTO(*v))
+}
+
+func (h *Handler) DiffPlanVersions(w http.ResponseWriter, r *http.Request, userID string, planID string) {
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	diff, err := h.workoutUsecase.DiffPlanVersions(r.Context(), userID, planID, from, to)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToPlanVersionDiffDTO(diff))
+}
+
+func (h *Handler) RestorePlanVersion(w http.ResponseWriter, r *http.Request, userID string, planID string, versionStr string) {
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+		return
+	}
+
+	plan, err := h.workoutUsecase.RestorePlanVersion(r.Context(), userID, planID, version)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToWorkoutPlanDetailDTO(*plan))
+}
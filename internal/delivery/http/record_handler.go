@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/pkg/response"
+)
+
+func (h *Handler) Records(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	book, err := h.recordUsecase.GetRecords(r.Context(), userID, r.URL.Query().Get("exercise_id"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.RecordBookDTO{
+		Current: httperr.ToPersonalRecordDTOs(book.Current),
+		History: httperr.ToPersonalRecordDTOs(book.History),
+	})
+}
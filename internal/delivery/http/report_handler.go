@@ -0,0 +1,162 @@
+package http
+
+import (
+	"net/http"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/pkg/response"
+)
+
+func (h *Handler) ReportSummary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	summary, err := h.reportUsecase.Summary(r.Context(), userID, from, to)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	if format != "" {
+		h.writeExport(w, r, format, "summary", httperr.SummaryDocument(*summary))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToReportSummaryDTO(*summary))
+}
+
+func (h *Handler) ReportProgress(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	q := r.URL.Query()
+	report, err := h.reportUsecase.Progress(r.Context(), userID, q.Get("exercise_id"), from, to, q.Get("formula"), q.Get("granularity"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	if format != "" {
+		h.writeExport(w, r, format, "progress", httperr.ProgressDocument(*report))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToProgressReportDTO(*report))
+}
+
+func (h *Handler) ReportBalance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	report, err := h.reportUsecase.Balance(r.Context(), userID, from, to)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	if format != "" {
+		h.writeExport(w, r, format, "balance", httperr.BalanceDocument(*report))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToBalanceReportDTO(*report))
+}
+
+func (h *Handler) ReportAdherence(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	report, err := h.reportUsecase.Adherence(r.Context(), userID, from, to, r.URL.Query().Get("granularity"))
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	if format != "" {
+		h.writeExport(w, r, format, "adherence", httperr.AdherenceDocument(*report))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToAdherenceReportDTO(*report))
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"math"
 	"time"
 
 	"workout-tracker/internal/domain"
@@ -14,10 +15,120 @@ type WorkoutPlanDTO struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type WorkoutPlanDetailDTO struct {
+	WorkoutPlanDTO
+	Exercises []WorkoutPlanExerciseDTO `json:"exercises"`
+}
+
+type WorkoutPlanExerciseDTO struct {
+	ID           string            `json:"id"`
+	ExerciseID   string            `json:"exercise_id"`
+	ExerciseName string            `json:"exercise_name"`
+	Category     string            `json:"category"`
+	MuscleGroup  string            `json:"muscle_group"`
+	Sets         int               `json:"sets"`
+	Reps         int               `json:"reps"`
+	Weight       float64           `json:"weight"`
+	OrderIndex   int               `json:"order_index"`
+	Group        *ExerciseGroupDTO `json:"group,omitempty"`
+	TrainingMax  *float64          `json:"training_max,omitempty"`
+	// SetPrescriptions is present when the exercise lists every set.
+	SetPrescriptions []SetPrescriptionDTO `json:"set_prescriptions,omitempty"`
+}
+
+type SetPrescriptionDTO struct {
+	Type    string   `json:"type"`
+	Reps    int      `json:"reps"`
+	RepsMax int      `json:"reps_max,omitempty"`
+	Weight  *float64 `json:"weight,omitempty"`
+	Percent *float64 `json:"percent,omitempty"`
+	RPE     *float64 `json:"rpe,omitempty"`
+	// TargetWeight is the load resolved against the training max.
+	TargetWeight *float64 `json:"target_weight,omitempty"`
+}
+
+type ExerciseGroupDTO struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Rounds      int    `json:"rounds"`
+	RestSeconds int    `json:"rest_seconds"`
+}
+
+type WorkoutPlanVersionDTO struct {
+	Version      int                      `json:"version"`
+	Name         string                   `json:"name"`
+	Notes        string                   `json:"notes"`
+	RestoredFrom *int                     `json:"restored_from,omitempty"`
+	CreatedAt    time.Time                `json:"created_at"`
+	Exercises    []WorkoutPlanExerciseDTO `json:"exercises,omitempty"`
+}
+
+type PlanVersionDiffDTO struct {
+	From         int                      `json:"from"`
+	To           int                      `json:"to"`
+	NameChanged  bool                     `json:"name_changed"`
+	NotesChanged bool                     `json:"notes_changed"`
+	Added        []WorkoutPlanExerciseDTO `json:"added"`
+	Removed      []WorkoutPlanExerciseDTO `json:"removed"`
+	Changed      []PlanExerciseChangeDTO  `json:"changed"`
+}
+
+type PlanExerciseChangeDTO struct {
+	ExerciseID   string                 `json:"exercise_id"`
+	ExerciseName string                 `json:"exercise_name"`
+	Fields       []string               `json:"fields"`
+	Before       WorkoutPlanExerciseDTO `json:"before"`
+	After        WorkoutPlanExerciseDTO `json:"after"`
+}
+
 type ScheduledWorkoutDTO struct {
+	ID               string    `json:"id"`
+	WorkoutPlanID    string    `json:"workout_plan_id"`
+	ScheduledDate    time.Time `json:"scheduled_date"`
+	ScheduledAt      time.Time `json:"scheduled_at"`
+	Timezone         string    `json:"timezone"`
+	AllDay           bool      `json:"all_day"`
+	Status           string    `json:"status"`
+	WorkoutSessionID string    `json:"workout_session_id,omitempty"`
+	CancelReason     string    `json:"cancel_reason,omitempty"`
+	SeriesID         string    `json:"series_id,omitempty"`
+	OccurrenceDate   string    `json:"occurrence_date,omitempty"`
+	Detached         bool      `json:"detached,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type AgendaItemDTO struct {
+	ScheduledWorkoutDTO
+	PlanName string `json:"plan_name"`
+}
+
+type CalendarViewDTO struct {
+	Timezone    string              `json:"timezone"`
+	Granularity string              `json:"granularity"`
+	From        string              `json:"from"`
+	To          string              `json:"to"`
+	Buckets     []CalendarBucketDTO `json:"buckets"`
+}
+
+type CalendarBucketDTO struct {
+	Start          string              `json:"start"`
+	End            string              `json:"end"`
+	Status         string              `json:"status,omitempty"`
+	ScheduledCount int                 `json:"scheduled_count"`
+	SessionCount   int                 `json:"session_count"`
+	StatusCounts   map[string]int      `json:"status_counts"`
+	Schedules      []AgendaItemDTO     `json:"schedules"`
+	Sessions       []WorkoutSessionDTO `json:"sessions"`
+}
+
+type ScheduleSeriesDTO struct {
 	ID            string    `json:"id"`
 	WorkoutPlanID string    `json:"workout_plan_id"`
-	ScheduledDate time.Time `json:"scheduled_date"`
+	StartsAt      time.Time `json:"starts_at"`
+	Timezone      string    `json:"timezone"`
+	AllDay        bool      `json:"all_day"`
+	RRule         string    `json:"rrule"`
+	ExDates       []string  `json:"exdates"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
@@ -31,11 +142,549 @@ func ToWorkoutPlanDTO(p domain.WorkoutPlan) WorkoutPlanDTO {
 	}
 }
 
+func ToWorkoutPlanDetailDTO(p domain.WorkoutPlanDetail) WorkoutPlanDetailDTO {
+	return WorkoutPlanDetailDTO{WorkoutPlanDTO: ToWorkoutPlanDTO(p.WorkoutPlan), Exercises: toWorkoutPlanExerciseDTOs(p.Exercises)}
+}
+
+func ToWorkoutPlanVersionDTO(v domain.WorkoutPlanVersion) WorkoutPlanVersionDTO {
+	dto := WorkoutPlanVersionDTO{
+		Version:      v.Version,
+		Name:         v.Name,
+		Notes:        v.Notes,
+		RestoredFrom: v.RestoredFrom,
+		CreatedAt:    v.CreatedAt,
+	}
+	if v.Exercises != nil {
+		dto.Exercises = toWorkoutPlanExerciseDTOs(v.Exercises)
+	}
+	return dto
+}
+
+func ToPlanVersionDiffDTO(d domain.PlanVersionDiff) PlanVersionDiffDTO {
+	changed := make([]PlanExerciseChangeDTO, 0, len(d.Changed))
+	for _, c := range d.Changed {
+		changed = append(changed, PlanExerciseChangeDTO{
+			ExerciseID:   c.After.ExerciseID,
+			ExerciseName: c.After.ExerciseName,
+			Fields:       c.Fields,
+			Before:       toWorkoutPlanExerciseDTO(c.Before),
+			After:        toWorkoutPlanExerciseDTO(c.After),
+		})
+	}
+
+	return PlanVersionDiffDTO{
+		From:         d.From,
+		To:           d.To,
+		NameChanged:  d.NameChanged,
+		NotesChanged: d.NotesChanged,
+		Added:        toWorkoutPlanExerciseDTOs(d.Added),
+		Removed:      toWorkoutPlanExerciseDTOs(d.Removed),
+		Changed:      changed,
+	}
+}
+
+func toWorkoutPlanExerciseDTOs(exercises []domain.WorkoutPlanExerciseDetail) []WorkoutPlanExerciseDTO {
+	out := make([]WorkoutPlanExerciseDTO, 0, len(exercises))
+	for _, ex := range exercises {
+		out = append(out, toWorkoutPlanExerciseDTO(ex))
+	}
+	return out
+}
+
+func toWorkoutPlanExerciseDTO(ex domain.WorkoutPlanExerciseDetail) WorkoutPlanExerciseDTO {
+	return WorkoutPlanExerciseDTO{
+		ID:               ex.ID,
+		ExerciseID:       ex.ExerciseID,
+		ExerciseName:     ex.ExerciseName,
+		Category:         ex.Category,
+		MuscleGroup:      ex.MuscleGroup,
+		Sets:             ex.Sets,
+		Reps:             ex.Reps,
+		Weight:           ex.Weight,
+		OrderIndex:       ex.OrderIndex,
+		Group:            toExerciseGroupDTO(ex.Group),
+		TrainingMax:      ex.TrainingMax,
+		SetPrescriptions: toSetPrescriptionDTOs(ex.WorkoutPlanExercise),
+	}
+}
+
+func toSetPrescriptionDTOs(ex domain.WorkoutPlanExercise) []SetPrescriptionDTO {
+	if len(ex.SetPrescriptions) == 0 {
+		return nil
+	}
+	out := make([]SetPrescriptionDTO, 0, len(ex.SetPrescriptions))
+	for _, p := range ex.SetPrescriptions {
+		out = append(out, SetPrescriptionDTO{
+			Type:         p.Type,
+			Reps:         p.Reps,
+			RepsMax:      p.RepsMax,
+			Weight:       p.Weight,
+			Percent:      p.Percent,
+			RPE:          p.RPE,
+			TargetWeight: ex.TargetWeight(p),
+		})
+	}
+	return out
+}
+
+func toExerciseGroupDTO(g *domain.ExerciseGroup) *ExerciseGroupDTO {
+	if g == nil {
+		return nil
+	}
+	return &ExerciseGroupDTO{ID: g.ID, Type: g.Type, Rounds: g.Rounds, RestSeconds: g.RestSeconds}
+}
+
 func ToScheduledWorkoutDTO(sw domain.ScheduledWorkout) ScheduledWorkoutDTO {
-	return ScheduledWorkoutDTO{
-		ID:            sw.ID,
-		WorkoutPlanID: sw.WorkoutPlanID,
-		ScheduledDate: sw.ScheduledDate,
-		CreatedAt:     sw.CreatedAt,
+	dto := ScheduledWorkoutDTO{
+		ID:               sw.ID,
+		WorkoutPlanID:    sw.WorkoutPlanID,
+		ScheduledDate:    sw.ScheduledDate,
+		ScheduledAt:      sw.ScheduledAt.In(sw.Location()),
+		Timezone:         sw.Timezone,
+		AllDay:           sw.AllDay,
+		Status:           sw.Status,
+		WorkoutSessionID: sw.WorkoutSessionID,
+		CancelReason:     sw.CancelReason,
+		SeriesID:         sw.SeriesID,
+		Detached:         sw.Detached,
+		CreatedAt:        sw.CreatedAt,
+	}
+	if sw.OccurrenceDate != nil {
+		dto.OccurrenceDate = sw.OccurrenceDate.Format("2006-01-02")
+	}
+	return dto
+}
+
+func ToAgendaItemDTO(item domain.AgendaItem) AgendaItemDTO {
+	return AgendaItemDTO{ScheduledWorkoutDTO: ToScheduledWorkoutDTO(item.ScheduledWorkout), PlanName: item.PlanName}
+}
+
+func ToCalendarViewDTO(v domain.CalendarView) CalendarViewDTO {
+	dto := CalendarViewDTO{
+		Timezone:    v.Timezone,
+		Granularity: v.Granularity,
+		From:        v.From.Format("2006-01-02"),
+		To:          v.To.Format("2006-01-02"),
+		Buckets:     make([]CalendarBucketDTO, 0, len(v.Buckets)),
+	}
+	for _, b := range v.Buckets {
+		bucket := CalendarBucketDTO{
+			Start:          b.Start.Format("2006-01-02"),
+			End:            b.End.Format("2006-01-02"),
+			Status:         b.Status(),
+			ScheduledCount: len(b.Schedules),
+			SessionCount:   len(b.Sessions),
+			StatusCounts:   b.StatusCounts,
+			Schedules:      make([]AgendaItemDTO, 0, len(b.Schedules)),
+			Sessions:       make([]WorkoutSessionDTO, 0, len(b.Sessions)),
+		}
+		for _, item := range b.Schedules {
+			bucket.Schedules = append(bucket.Schedules, ToAgendaItemDTO(item))
+		}
+		for _, s := range b.Sessions {
+			bucket.Sessions = append(bucket.Sessions, ToWorkoutSessionDTO(s))
+		}
+		dto.Buckets = append(dto.Buckets, bucket)
+	}
+	return dto
+}
+
+func ToScheduleSeriesDTO(s domain.ScheduleSeries) ScheduleSeriesDTO {
+	exdates := make([]string, 0, len(s.ExDates))
+	for _, d := range s.ExDates {
+		exdates = append(exdates, d.Format("2006-01-02"))
+	}
+
+	return ScheduleSeriesDTO{
+		ID:            s.ID,
+		WorkoutPlanID: s.WorkoutPlanID,
+		StartsAt:      s.StartsAt.In(s.Location()),
+		Timezone:      s.Timezone,
+		AllDay:        s.AllDay,
+		RRule:         s.RRule,
+		ExDates:       exdates,
+		CreatedAt:     s.CreatedAt,
+	}
+}
+
+type WorkoutSessionDTO struct {
+	ID            string                      `json:"id"`
+	WorkoutPlanID string                      `json:"workout_plan_id,omitempty"`
+	PlanVersion   int                         `json:"plan_version,omitempty"`
+	Status        string                      `json:"status"`
+	StartedAt     time.Time                   `json:"started_at"`
+	CompletedAt   *time.Time                  `json:"completed_at,omitempty"`
+	Notes         string                      `json:"notes"`
+	Exercises     []WorkoutSessionExerciseDTO `json:"exercises,omitempty"`
+}
+
+type WorkoutSessionExerciseDTO struct {
+	ID           string                 `json:"id"`
+	ExerciseID   string                 `json:"exercise_id"`
+	Sets         int                    `json:"sets"`
+	Reps         int                    `json:"reps"`
+	Weight       float64                `json:"weight"`
+	ActualReps   *int                   `json:"actual_reps"`
+	ActualWeight *float64               `json:"actual_weight"`
+	OrderIndex   int                    `json:"order_index"`
+	Group        *ExerciseGroupDTO      `json:"group,omitempty"`
+	SetLogs      []WorkoutSessionSetDTO `json:"set_logs"`
+}
+
+type WorkoutSessionSetDTO struct {
+	ID            string     `json:"id"`
+	SetNumber     int        `json:"set_number"`
+	TargetReps    *int       `json:"target_reps"`
+	TargetWeight  *float64   `json:"target_weight"`
+	ActualReps    *int       `json:"actual_reps"`
+	ActualWeight  *float64   `json:"actual_weight"`
+	RPE           *float64   `json:"rpe"`
+	RIR           *int       `json:"rir"`
+	IsWarmup      bool       `json:"is_warmup"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	SetType       string     `json:"set_type,omitempty"`
+	TargetRepsMax *int       `json:"target_reps_max,omitempty"`
+	TargetRPE     *float64   `json:"target_rpe,omitempty"`
+}
+
+func ToWorkoutSessionDTO(s domain.WorkoutSession) WorkoutSessionDTO {
+	dto := WorkoutSessionDTO{
+		ID:            s.ID,
+		WorkoutPlanID: s.WorkoutPlanID,
+		PlanVersion:   s.PlanVersion,
+		Status:        s.Status(),
+		StartedAt:     s.StartedAt,
+		CompletedAt:   s.CompletedAt,
+		Notes:         s.Notes,
+	}
+
+	if s.Exercises != nil {
+		dto.Exercises = make([]WorkoutSessionExerciseDTO, 0, len(s.Exercises))
+		for _, ex := range s.Exercises {
+			dto.Exercises = append(dto.Exercises, ToWorkoutSessionExerciseDTO(ex))
+		}
+	}
+
+	return dto
+}
+
+func ToWorkoutSessionExerciseDTO(ex domain.WorkoutSessionExercise) WorkoutSessionExerciseDTO {
+	sets := make([]WorkoutSessionSetDTO, 0, len(ex.SetLogs))
+	for _, st := range ex.SetLogs {
+		sets = append(sets, ToWorkoutSessionSetDTO(st))
+	}
+
+	return WorkoutSessionExerciseDTO{
+		ID:           ex.ID,
+		ExerciseID:   ex.ExerciseID,
+		Sets:         ex.Sets,
+		Reps:         ex.Reps,
+		Weight:       ex.Weight,
+		ActualReps:   ex.ActualReps,
+		ActualWeight: ex.ActualWeight,
+		OrderIndex:   ex.OrderIndex,
+		Group:        toExerciseGroupDTO(ex.Group),
+		SetLogs:      sets,
+	}
+}
+
+func ToWorkoutSessionSetDTO(st domain.WorkoutSessionSet) WorkoutSessionSetDTO {
+	return WorkoutSessionSetDTO{
+		ID:            st.ID,
+		SetNumber:     st.SetNumber,
+		TargetReps:    st.TargetReps,
+		TargetWeight:  st.TargetWeight,
+		ActualReps:    st.ActualReps,
+		ActualWeight:  st.ActualWeight,
+		RPE:           st.RPE,
+		RIR:           st.RIR,
+		IsWarmup:      st.IsWarmup,
+		CompletedAt:   st.CompletedAt,
+		SetType:       st.SetType,
+		TargetRepsMax: st.TargetRepsMax,
+		TargetRPE:     st.TargetRPE,
+	}
+}
+
+type TrainingTotalsDTO struct {
+	TotalSessions        int     `json:"total_sessions"`
+	TotalDurationSeconds int64   `json:"total_duration_seconds"`
+	TotalVolume          float64 `json:"total_volume"`
+	DistinctExercises    int     `json:"distinct_exercises"`
+}
+
+type WeeklySummaryDTO struct {
+	WeekStart string `json:"week_start"`
+	TrainingTotalsDTO
+}
+
+type ReportSummaryDTO struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Timezone string `json:"timezone"`
+	TrainingTotalsDTO
+	Weeks []WeeklySummaryDTO `json:"weeks"`
+}
+
+func ToTrainingTotalsDTO(t domain.TrainingTotals) TrainingTotalsDTO {
+	return TrainingTotalsDTO{
+		TotalSessions:        t.Sessions,
+		TotalDurationSeconds: int64(t.Duration / time.Second),
+		TotalVolume:          t.Volume,
+		DistinctExercises:    t.DistinctExercises,
+	}
+}
+
+func ToReportSummaryDTO(s domain.ReportSummary) ReportSummaryDTO {
+	dto := ReportSummaryDTO{
+		From:              s.From.Format("2006-01-02"),
+		To:                s.To.Format("2006-01-02"),
+		Timezone:          s.Timezone,
+		TrainingTotalsDTO: ToTrainingTotalsDTO(s.TrainingTotals),
+		Weeks:             make([]WeeklySummaryDTO, 0, len(s.Weeks)),
+	}
+	for _, w := range s.Weeks {
+		dto.Weeks = append(dto.Weeks, WeeklySummaryDTO{WeekStart: w.WeekStart.Format("2006-01-02"), TrainingTotalsDTO: ToTrainingTotalsDTO(w.TrainingTotals)})
+	}
+	return dto
+}
+
+type PerformedSetDTO struct {
+	SessionID string  `json:"session_id"`
+	Reps      int     `json:"reps"`
+	Weight    float64 `json:"weight"`
+}
+
+type ProgressPointDTO struct {
+	Start     string          `json:"start"`
+	SessionID string          `json:"session_id,omitempty"`
+	StartedAt *time.Time      `json:"started_at,omitempty"`
+	Sessions  int             `json:"sessions"`
+	BestSet   PerformedSetDTO `json:"best_set"`
+	TopWeight float64         `json:"top_weight"`
+	Volume    float64         `json:"total_volume"`
+	OneRepMax float64         `json:"estimated_1rm"`
+}
+
+type ProgressReportDTO struct {
+	ExerciseID   string             `json:"exercise_id"`
+	ExerciseName string             `json:"exercise_name"`
+	Formula      string             `json:"formula"`
+	Granularity  string             `json:"granularity"`
+	Timezone     string             `json:"timezone"`
+	Points       []ProgressPointDTO `json:"points"`
+}
+
+func ToProgressReportDTO(r domain.ProgressReport) ProgressReportDTO {
+	dto := ProgressReportDTO{
+		ExerciseID:   r.Exercise.ID,
+		ExerciseName: r.Exercise.Name,
+		Formula:      r.Formula,
+		Granularity:  r.Granularity,
+		Timezone:     r.Timezone,
+		Points:       make([]ProgressPointDTO, 0, len(r.Points)),
+	}
+	for _, p := range r.Points {
+		point := ProgressPointDTO{
+			Start:     p.Start.Format("2006-01-02"),
+			SessionID: p.SessionID,
+			Sessions:  p.Sessions,
+			BestSet:   PerformedSetDTO{SessionID: p.BestSet.SessionID, Reps: p.BestSet.Reps, Weight: p.BestSet.Weight},
+			TopWeight: p.TopWeight,
+			Volume:    p.Volume,
+			OneRepMax: math.Round(p.OneRepMax*100) / 100,
+		}
+		if p.SessionID != "" {
+			startedAt := p.BestSet.StartedAt
+			point.StartedAt = &startedAt
+		}
+		dto.Points = append(dto.Points, point)
+	}
+	return dto
+}
+
+type PersonalRecordDTO struct {
+	ID            string    `json:"id"`
+	ExerciseID    string    `json:"exercise_id"`
+	ExerciseName  string    `json:"exercise_name"`
+	SessionID     string    `json:"session_id"`
+	Type          string    `json:"type"`
+	Value         float64   `json:"value"`
+	PreviousValue *float64  `json:"previous_value"`
+	Weight        float64   `json:"weight"`
+	Reps          int       `json:"reps"`
+	AchievedAt    time.Time `json:"achieved_at"`
+}
+
+type FinishedSessionDTO struct {
+	WorkoutSessionDTO
+	PersonalRecords []PersonalRecordDTO `json:"personal_records"`
+}
+
+type RecordBookDTO struct {
+	Current []PersonalRecordDTO `json:"current"`
+	History []PersonalRecordDTO `json:"history"`
+}
+
+func ToPersonalRecordDTOs(records []domain.PersonalRecord) []PersonalRecordDTO {
+	out := make([]PersonalRecordDTO, 0, len(records))
+	for _, pr := range records {
+		out = append(out, PersonalRecordDTO{
+			ID:            pr.ID,
+			ExerciseID:    pr.ExerciseID,
+			ExerciseName:  pr.ExerciseName,
+			SessionID:     pr.SessionID,
+			Type:          pr.Type,
+			Value:         pr.Value,
+			PreviousValue: pr.Previous,
+			Weight:        pr.Weight,
+			Reps:          pr.Reps,
+			AchievedAt:    pr.AchievedAt,
+		})
+	}
+	return out
+}
+
+type TrainingLoadDTO struct {
+	Sets   int     `json:"sets"`
+	Volume float64 `json:"volume"`
+}
+
+type WeeklyBalanceDTO struct {
+	WeekStart    string                     `json:"week_start"`
+	MuscleGroups map[string]TrainingLoadDTO `json:"muscle_groups"`
+	Categories   map[string]TrainingLoadDTO `json:"categories"`
+}
+
+type BalanceRatioDTO struct {
+	Name            string   `json:"name"`
+	Numerator       []string `json:"numerator"`
+	Denominator     []string `json:"denominator"`
+	NumeratorSets   int      `json:"numerator_sets"`
+	DenominatorSets int      `json:"denominator_sets"`
+	Ratio           *float64 `json:"ratio"`
+	Min             float64  `json:"min"`
+	Max             float64  `json:"max"`
+	Imbalanced      bool     `json:"imbalanced"`
+}
+
+type BalanceReportDTO struct {
+	From         string                     `json:"from"`
+	To           string                     `json:"to"`
+	Timezone     string                     `json:"timezone"`
+	MuscleGroups map[string]TrainingLoadDTO `json:"muscle_groups"`
+	Categories   map[string]TrainingLoadDTO `json:"categories"`
+	Weeks        []WeeklyBalanceDTO         `json:"weeks"`
+	Ratios       []BalanceRatioDTO          `json:"ratios"`
+	Neglected    []string                   `json:"neglected_muscle_groups"`
+}
+
+func toTrainingLoadDTOs(loads map[string]domain.TrainingLoad) map[string]TrainingLoadDTO {
+	out := make(map[string]TrainingLoadDTO, len(loads))
+	for k, l := range loads {
+		out[k] = TrainingLoadDTO{Sets: l.Sets, Volume: l.Volume}
+	}
+	return out
+}
+
+// roundRatio rounds to two decimals for display.
+func roundRatio(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := math.Round(*v*100) / 100
+	return &r
+}
+
+func ToBalanceReportDTO(r domain.BalanceReport) BalanceReportDTO {
+	dto := BalanceReportDTO{
+		From:         r.From.Format("2006-01-02"),
+		To:           r.To.Format("2006-01-02"),
+		Timezone:     r.Timezone,
+		MuscleGroups: toTrainingLoadDTOs(r.MuscleGroups),
+		Categories:   toTrainingLoadDTOs(r.Categories),
+		Weeks:        make([]WeeklyBalanceDTO, 0, len(r.Weeks)),
+		Ratios:       make([]BalanceRatioDTO, 0, len(r.Ratios)),
+		Neglected:    r.Neglected,
+	}
+	for _, w := range r.Weeks {
+		dto.Weeks = append(dto.Weeks, WeeklyBalanceDTO{
+			WeekStart:    w.WeekStart.Format("2006-01-02"),
+			MuscleGroups: toTrainingLoadDTOs(w.MuscleGroups),
+			Categories:   toTrainingLoadDTOs(w.Categories),
+		})
+	}
+	for _, ratio := range r.Ratios {
+		dto.Ratios = append(dto.Ratios, BalanceRatioDTO{
+			Name:            ratio.Rule.Name,
+			Numerator:       ratio.Rule.Numerator,
+			Denominator:     ratio.Rule.Denominator,
+			NumeratorSets:   ratio.NumeratorSets,
+			DenominatorSets: ratio.DenominatorSets,
+			Ratio:           roundRatio(ratio.Ratio),
+			Min:             math.Round(ratio.Rule.Min*100) / 100,
+			Max:             ratio.Rule.Max,
+			Imbalanced:      ratio.Imbalanced,
+		})
+	}
+	return dto
+}
+
+type AdherencePeriodDTO struct {
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Scheduled int      `json:"scheduled"`
+	Completed int      `json:"completed"`
+	Missed    int      `json:"missed"`
+	Pending   int      `json:"pending"`
+	Canceled  int      `json:"canceled"`
+	Sessions  int      `json:"sessions"`
+	Adherence *float64 `json:"adherence_percent"`
+}
+
+type StreakDTO struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+type AdherenceReportDTO struct {
+	From         string               `json:"from"`
+	To           string               `json:"to"`
+	Timezone     string               `json:"timezone"`
+	Granularity  string               `json:"granularity"`
+	Totals       AdherencePeriodDTO   `json:"totals"`
+	Periods      []AdherencePeriodDTO `json:"periods"`
+	DailyStreak  StreakDTO            `json:"daily_streak"`
+	WeeklyStreak StreakDTO            `json:"weekly_streak"`
+}
+
+func ToAdherencePeriodDTO(p domain.AdherencePeriod) AdherencePeriodDTO {
+	return AdherencePeriodDTO{
+		Start:     p.Start.Format("2006-01-02"),
+		End:       p.End.Format("2006-01-02"),
+		Scheduled: p.Scheduled,
+		Completed: p.Completed,
+		Missed:    p.Missed,
+		Pending:   p.Pending,
+		Canceled:  p.Canceled,
+		Sessions:  p.Sessions,
+		Adherence: roundRatio(p.Rate()),
+	}
+}
+
+func ToAdherenceReportDTO(r domain.AdherenceReport) AdherenceReportDTO {
+	dto := AdherenceReportDTO{
+		From:         r.From.Format("2006-01-02"),
+		To:           r.To.Format("2006-01-02"),
+		Timezone:     r.Timezone,
+		Granularity:  r.Granularity,
+		Totals:       ToAdherencePeriodDTO(r.Totals),
+		Periods:      make([]AdherencePeriodDTO, 0, len(r.Periods)),
+		DailyStreak:  StreakDTO{Current: r.DailyStreak.Current, Longest: r.DailyStreak.Longest},
+		WeeklyStreak: StreakDTO{Current: r.WeeklyStreak.Current, Longest: r.WeeklyStreak.Longest},
+	}
+	for _, p := range r.Periods {
+		dto.Periods = append(dto.Periods, ToAdherencePeriodDTO(p))
 	}
+	return dto
 }
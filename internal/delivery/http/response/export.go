@@ -0,0 +1,233 @@
+package response
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/platform/export"
+)
+
+func rangeSubtitle(from, to time.Time, timezone string) string {
+	return fmt.Sprintf("%s to %s (%s)", export.Date(from), export.Date(to), timezone)
+}
+
+func totalsRow(t domain.TrainingTotals) []string {
+	return []string{
+		strconv.Itoa(t.Sessions),
+		export.Number(t.Duration.Minutes()),
+		export.Number(t.Volume),
+		strconv.Itoa(t.DistinctExercises),
+	}
+}
+
+var totalsColumns = []string{"sessions", "duration_minutes", "volume", "distinct_exercises"}
+
+func SummaryDocument(s domain.ReportSummary) export.Document {
+	weeks := export.Table{Title: "Weeks", Columns: append([]string{"week_start"}, totalsColumns...)}
+	volume := export.Chart{Title: "Weekly volume", Series: []export.Series{{Name: "Volume"}}}
+	for _, w := range s.Weeks {
+		weeks.Rows = append(weeks.Rows, append([]string{export.Date(w.WeekStart)}, totalsRow(w.TrainingTotals)...))
+		volume.Labels = append(volume.Labels, export.Date(w.WeekStart))
+		volume.Series[0].Values = append(volume.Series[0].Values, w.Volume)
+	}
+
+	return export.Document{
+		Title:    "Training summary",
+		Subtitle: rangeSubtitle(s.From, s.To, s.Timezone),
+		Charts:   []export.Chart{volume},
+		Tables: []export.Table{
+			{Title: "Totals", Columns: totalsColumns, Rows: [][]string{totalsRow(s.TrainingTotals)}},
+			weeks,
+		},
+	}
+}
+
+func ProgressDocument(r domain.ProgressReport) export.Document {
+	table := export.Table{
+		Title:   "Progress",
+		Columns: []string{"start", "session_id", "sessions", "best_set_reps", "best_set_weight", "top_weight", "volume", "estimated_1rm"},
+	}
+	chart := export.Chart{
+		Title:  "Estimated 1RM and top weight",
+		Series: []export.Series{{Name: "Estimated 1RM (" + r.Formula + ")"}, {Name: "Top weight"}},
+	}
+	for _, p := range r.Points {
+		table.Rows = append(table.Rows, []string{
+			export.Date(p.Start),
+			p.SessionID,
+			strconv.Itoa(p.Sessions),
+			strconv.Itoa(p.BestSet.Reps),
+			export.Number(p.BestSet.Weight),
+			export.Number(p.TopWeight),
+			export.Number(p.Volume),
+			export.Number(p.OneRepMax),
+		})
+		chart.Labels = append(chart.Labels, export.Date(p.Start))
+		chart.Series[0].Values = append(chart.Series[0].Values, p.OneRepMax)
+		chart.Series[1].Values = append(chart.Series[1].Values, p.TopWeight)
+	}
+
+	return export.Document{
+		Title:    "Progress: " + r.Exercise.Name,
+		Subtitle: fmt.Sprintf("Per %s, %s", r.Granularity, r.Timezone),
+		Charts:   []export.Chart{chart},
+		Tables:   []export.Table{table},
+	}
+}
+
+func loadTable(title, key string, loads map[string]domain.TrainingLoad) export.Table {
+	t := export.Table{Title: title, Columns: []string{key, "sets", "volume"}}
+	for _, k := range sortedKeys(loads) {
+		t.Rows = append(t.Rows, []string{k, strconv.Itoa(loads[k].Sets), export.Number(loads[k].Volume)})
+	}
+	return t
+}
+
+func BalanceDocument(r domain.BalanceReport) export.Document {
+	ratios := export.Table{
+		Title:   "Ratios",
+		Columns: []string{"ratio", "numerator_sets", "denominator_sets", "value", "min", "max", "imbalanced"},
+	}
+	for _, ratio := range r.Ratios {
+		ratios.Rows = append(ratios.Rows, []string{
+			ratio.Rule.Name,
+			strconv.Itoa(ratio.NumeratorSets),
+			strconv.Itoa(ratio.DenominatorSets),
+			export.Optional(ratio.Ratio),
+			export.Number(ratio.Rule.Min),
+			export.Number(ratio.Rule.Max),
+			strconv.FormatBool(ratio.Imbalanced),
+		})
+	}
+
+	weekly := export.Table{Title: "Weekly sets per muscle group", Columns: []string{"week_start", "muscle_group", "sets", "volume"}}
+	for _, w := range r.Weeks {
+		for _, k := range sortedKeys(w.MuscleGroups) {
+			weekly.Rows = append(weekly.Rows, []string{export.Date(w.WeekStart), k, strconv.Itoa(w.MuscleGroups[k].Sets), export.Number(w.MuscleGroups[k].Volume)})
+		}
+	}
+
+	neglected := export.Table{Title: "Neglected muscle groups", Columns: []string{"muscle_group"}}
+	for _, g := range r.Neglected {
+		neglected.Rows = append(neglected.Rows, []string{g})
+	}
+
+	return export.Document{
+		Title:    "Muscle group balance",
+		Subtitle: rangeSubtitle(r.From, r.To, r.Timezone),
+		Tables: []export.Table{
+			loadTable("Muscle groups", "muscle_group", r.MuscleGroups),
+			loadTable("Categories", "category", r.Categories),
+			ratios,
+			weekly,
+			neglected,
+		},
+	}
+}
+
+func adherenceRow(p domain.AdherencePeriod) []string {
+	return []string{
+		export.Date(p.Start),
+		export.Date(p.End),
+		strconv.Itoa(p.Scheduled),
+		strconv.Itoa(p.Completed),
+		strconv.Itoa(p.Missed),
+		strconv.Itoa(p.Pending),
+		strconv.Itoa(p.Canceled),
+		strconv.Itoa(p.Sessions),
+		export.Optional(p.Rate()),
+	}
+}
+
+func AdherenceDocument(r domain.AdherenceReport) export.Document {
+	columns := []string{"start", "end", "scheduled", "completed", "missed", "pending", "canceled", "sessions", "adherence_percent"}
+	periods := export.Table{Title: "Periods", Columns: columns}
+	chart := export.Chart{Title: "Adherence (%)", Series: []export.Series{{Name: "Adherence"}}}
+	for _, p := range r.Periods {
+		periods.Rows = append(periods.Rows, adherenceRow(p))
+		chart.Labels = append(chart.Labels, export.Date(p.Start))
+		rate := math.NaN()
+		if v := p.Rate(); v != nil {
+			rate = *v
+		}
+		chart.Series[0].Values = append(chart.Series[0].Values, rate)
+	}
+
+	return export.Document{
+		Title:    "Schedule adherence",
+		Subtitle: rangeSubtitle(r.From, r.To, r.Timezone),
+		Charts:   []export.Chart{chart},
+		Tables: []export.Table{
+			{Title: "Totals", Columns: columns, Rows: [][]string{adherenceRow(r.Totals)}},
+			periods,
+			{
+				Title:   "Streaks",
+				Columns: []string{"streak", "current", "longest"},
+				Rows: [][]string{
+					{"daily", strconv.Itoa(r.DailyStreak.Current), strconv.Itoa(r.DailyStreak.Longest)},
+					{"weekly", strconv.Itoa(r.WeeklyStreak.Current), strconv.Itoa(r.WeeklyStreak.Longest)},
+				},
+			},
+		},
+	}
+}
+
+func SessionHistoryDocument(h domain.SessionHistory) export.Document {
+	loc, err := domain.LoadTimezone(h.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	table := export.Table{
+		Title:   "Sessions",
+		Columns: []string{"session_id", "started_at", "completed_at", "plan", "exercise", "set", "reps", "weight", "warmup", "rpe"},
+	}
+	for _, e := range h.Entries {
+		set, reps, weight, warmup := "", "", "", ""
+		if e.ExerciseName != "" {
+			set = strconv.Itoa(e.SetNumber)
+			reps = strconv.Itoa(e.Reps)
+			weight = export.Number(e.Weight)
+			warmup = strconv.FormatBool(e.IsWarmup)
+		}
+		table.Rows = append(table.Rows, []string{
+			e.SessionID,
+			export.Timestamp(e.StartedAt, loc),
+			export.Timestamp(e.CompletedAt, loc),
+			e.PlanName,
+			e.ExerciseName,
+			set,
+			reps,
+			weight,
+			warmup,
+			export.Optional(e.RPE),
+		})
+	}
+
+	subtitle := "All finished sessions (" + h.Timezone + ")"
+	if h.From != nil || h.To != nil {
+		from, to := "start", "today"
+		if h.From != nil {
+			from = export.Date(*h.From)
+		}
+		if h.To != nil {
+			to = export.Date(*h.To)
+		}
+		subtitle = fmt.Sprintf("%s to %s (%s)", from, to, h.Timezone)
+	}
+
+	return export.Document{Title: "Session history", Subtitle: subtitle, Tables: []export.Table{table}}
+}
+
+func sortedKeys(m map[string]domain.TrainingLoad) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
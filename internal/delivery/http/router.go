@@ -16,14 +16,25 @@ func NewRouter(handler *Handler, jwtService *auth.JWTService) http.Handler {
 
 	mux.HandleFunc("/auth/register", handler.Register)
 	mux.HandleFunc("/auth/login", handler.Login)
+	mux.HandleFunc("/calendar/", handler.CalendarICS)
 
 	jwtMiddleware := JWTMiddleware(jwtService)
 	mux.Handle("/api/me", jwtMiddleware(http.HandlerFunc(handler.Me)))
 	mux.Handle("/api/exercises", jwtMiddleware(http.HandlerFunc(handler.Exercises)))
 	mux.Handle("/api/workouts/schedule", jwtMiddleware(http.HandlerFunc(handler.ScheduledWorkouts)))
-	mux.Handle("/api/workouts/schedule/", jwtMiddleware(http.HandlerFunc(handler.DeleteScheduledWorkout)))
+	mux.Handle("/api/workouts/schedule/", jwtMiddleware(http.HandlerFunc(handler.ScheduledWorkoutByID)))
 	mux.Handle("/api/workouts", jwtMiddleware(http.HandlerFunc(handler.Workouts)))
 	mux.Handle("/api/workouts/", jwtMiddleware(http.HandlerFunc(handler.WorkoutByID)))
+	mux.Handle("/api/sessions", jwtMiddleware(http.HandlerFunc(handler.Sessions)))
+	mux.Handle("/api/sessions/start", jwtMiddleware(http.HandlerFunc(handler.StartSession)))
+	mux.Handle("/api/sessions/", jwtMiddleware(http.HandlerFunc(handler.SessionByID)))
+	mux.Handle("/api/calendar", jwtMiddleware(http.HandlerFunc(handler.CalendarView)))
+	mux.Handle("/api/calendar/feed", jwtMiddleware(http.HandlerFunc(handler.CalendarFeed)))
+	mux.Handle("/api/reports/summary", jwtMiddleware(http.HandlerFunc(handler.ReportSummary)))
+	mux.Handle("/api/reports/progress", jwtMiddleware(http.HandlerFunc(handler.ReportProgress)))
+	mux.Handle("/api/reports/balance", jwtMiddleware(http.HandlerFunc(handler.ReportBalance)))
+	mux.Handle("/api/reports/adherence", jwtMiddleware(http.HandlerFunc(handler.ReportAdherence)))
+	mux.Handle("/api/records", jwtMiddleware(http.HandlerFunc(handler.Records)))
 
 	return mux
 }
@@ -0,0 +1,339 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/pkg/response"
+)
+
+type CompleteScheduleRequest struct {
+	WorkoutSessionID string `json:"workout_session_id"`
+}
+
+type CancelScheduleRequest struct {
+	Reason string `json:"reason"`
+}
+
+type UpdateScheduleRequest struct {
+	WorkoutPlanID *string `json:"workout_plan_id"`
+	ScheduledAt   *string `json:"scheduled_at"`
+	ScheduledDate *string `json:"scheduled_date"`
+	Timezone      *string `json:"timezone"`
+}
+
+type UpdateScheduleSeriesRequest struct {
+	UpdateScheduleRequest
+	RRule   *string   `json:"rrule"`
+	ExDates *[]string `json:"exdates"`
+}
+
+func (req UpdateScheduleRequest) toChange() (domain.ScheduleChange, error) {
+	change := domain.ScheduleChange{
+		WorkoutPlanID: req.WorkoutPlanID,
+		Timezone:      req.Timezone,
+	}
+
+	if req.ScheduledAt != nil || req.ScheduledDate != nil {
+		var at, date string
+		if req.ScheduledAt != nil {
+			at = *req.ScheduledAt
+		}
+		if req.ScheduledDate != nil {
+			date = *req.ScheduledDate
+		}
+		t, allDay, err := parseScheduleTime(at, date)
+		if err != nil {
+			return domain.ScheduleChange{}, err
+		}
+		change.ScheduledAt = &t
+		change.AllDay = allDay
+	}
+
+	return change, nil
+}
+
+func (req UpdateScheduleSeriesRequest) toChange() (domain.ScheduleSeriesChange, error) {
+	base, err := req.UpdateScheduleRequest.toChange()
+	if err != nil {
+		return domain.ScheduleSeriesChange{}, err
+	}
+
+	change := domain.ScheduleSeriesChange{ScheduleChange: base, RRule: req.RRule}
+	if req.ExDates != nil {
+		dates, err := parseDates(*req.ExDates)
+		if err != nil {
+			return domain.ScheduleSeriesChange{}, err
+		}
+		change.ExDates = &dates
+	}
+
+	return change, nil
+}
+
+// parseScheduleTime accepts exactly one of an RFC 3339 timestamp or an
+// all-day YYYY-MM-DD date.
+func parseScheduleTime(at, date string) (time.Time, bool, error) {
+	at = strings.TrimSpace(at)
+	date = strings.TrimSpace(date)
+	if (at == "") == (date == "") {
+		return time.Time{}, false, domain.ErrInvalidInput
+	}
+
+	if at != "" {
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return time.Time{}, false, domain.ErrInvalidInput
+		}
+		return t, false, nil
+	}
+
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, false, domain.ErrInvalidInput
+	}
+	return t, true, nil
+}
+
+func parseDates(values []string) ([]time.Time, error) {
+	out := make([]time.Time, 0, len(values))
+	for _, v := range values {
+		t, err := time.Parse("2006-01-02", strings.TrimSpace(v))
+		if err != nil {
+			return nil, domain.ErrInvalidInput
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (h *Handler) ScheduledWorkoutByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	parts := pathSegments(r, "/api/workouts/schedule/")
+	if len(parts) > 1 && parts[0] == "series" {
+		h.scheduleSeriesRoutes(w, r, userID, parts[1:])
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && parts[0] == "agenda" && r.Method == http.MethodGet:
+		h.ScheduleAgenda(w, r, userID)
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		h.RescheduleWorkout(w, r, userID, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		h.DeleteScheduledWorkout(w, r, userID, parts[0])
+	case len(parts) == 2 && parts[1] == "complete" && r.Method == http.MethodPost:
+		h.CompleteScheduledWorkout(w, r, userID, parts[0])
+	case len(parts) == 2 && parts[1] == "cancel" && r.Method == http.MethodPost:
+		h.CancelScheduledWorkout(w, r, userID, parts[0])
+	case len(parts) == 2 && parts[1] == "reopen" && r.Method == http.MethodPost:
+		h.ReopenScheduledWorkout(w, r, userID, parts[0])
+	case len(parts) == 1:
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+	default:
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+	}
+}
+
+func (h *Handler) ScheduleAgenda(w http.ResponseWriter, r *http.Request, userID string) {
+	p, err := parsePagination(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	filter, err := parseScheduleFilter(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	res, err := h.scheduledWorkoutUsecase.GetAgenda(r.Context(), userID, p, filter)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	data := make([]httperr.AgendaItemDTO, 0, len(res.Data))
+	for _, item := range res.Data {
+		data = append(data, httperr.ToAgendaItemDTO(item))
+	}
+
+	response.JSON(w, http.StatusOK, httperr.PaginatedResponse[httperr.AgendaItemDTO]{
+		Data: data,
+		Meta: httperr.PaginationMeta{Total: res.Total, Page: res.Page, Limit: res.Limit, TotalPages: res.TotalPages},
+	})
+}
+
+func (h *Handler) RescheduleWorkout(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	var req UpdateScheduleRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	change, err := req.toChange()
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	sw, err := h.scheduledWorkoutUsecase.RescheduleWorkout(r.Context(), id, userID, change)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduledWorkoutDTO(*sw))
+}
+
+func (h *Handler) CompleteScheduledWorkout(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	var req CompleteScheduleRequest
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	sw, err := h.scheduledWorkoutUsecase.CompleteSchedule(r.Context(), id, userID, req.WorkoutSessionID)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduledWorkoutDTO(*sw))
+}
+
+func (h *Handler) CancelScheduledWorkout(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	var req CancelScheduleRequest
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	sw, err := h.scheduledWorkoutUsecase.CancelSchedule(r.Context(), id, userID, req.Reason)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduledWorkoutDTO(*sw))
+}
+
+func (h *Handler) ReopenScheduledWorkout(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	sw, err := h.scheduledWorkoutUsecase.ReopenSchedule(r.Context(), id, userID)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduledWorkoutDTO(*sw))
+}
+
+func (h *Handler) scheduleSeriesRoutes(w http.ResponseWriter, r *http.Request, userID string, parts []string) {
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.GetScheduleSeries(w, r, userID, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodPatch:
+		h.UpdateScheduleSeries(w, r, userID, parts[0])
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		h.DeleteScheduleSeries(w, r, userID, parts[0])
+	case len(parts) == 3 && parts[1] == "occurrences" && r.Method == http.MethodPatch:
+		h.EditScheduleOccurrence(w, r, userID, parts[0], parts[2])
+	case len(parts) == 1 || len(parts) == 3:
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+	default:
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+	}
+}
+
+func (h *Handler) GetScheduleSeries(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	s, err := h.scheduledWorkoutUsecase.GetSeries(r.Context(), id, userID)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduleSeriesDTO(*s))
+}
+
+func (h *Handler) UpdateScheduleSeries(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	var req UpdateScheduleSeriesRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	change, err := req.toChange()
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	s, err := h.scheduledWorkoutUsecase.UpdateSeries(r.Context(), id, userID, change)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduleSeriesDTO(*s))
+}
+
+func (h *Handler) DeleteScheduleSeries(w http.ResponseWriter, r *http.Request, userID string, id string) {
+	if err := h.scheduledWorkoutUsecase.DeleteSeries(r.Context(), id, userID); err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+func (h *Handler) EditScheduleOccurrence(w http.ResponseWriter, r *http.Request, userID string, seriesID string, dateParam string) {
+	date, err := time.Parse("2006-01-02", dateParam)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	var req UpdateScheduleRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	change, err := req.toChange()
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	sw, err := h.scheduledWorkoutUsecase.EditOccurrence(r.Context(), seriesID, userID, date, change)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToScheduledWorkoutDTO(*sw))
+}
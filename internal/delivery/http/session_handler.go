@@ -0,0 +1,319 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	httperr "workout-tracker/internal/delivery/http/response"
+	"workout-tracker/internal/domain"
+	"workout-tracker/pkg/response"
+)
+
+type StartSessionRequest struct {
+	WorkoutPlanID string `json:"workout_plan_id"`
+	Notes         string `json:"notes"`
+}
+
+type AddSessionExerciseRequest struct {
+	ExerciseID string  `json:"exercise_id"`
+	Sets       int     `json:"sets"`
+	Reps       int     `json:"reps"`
+	Weight     float64 `json:"weight"`
+}
+
+type SaveSessionAsPlanRequest struct {
+	Name  string `json:"name"`
+	Notes string `json:"notes"`
+}
+
+type FinishSessionRequest struct {
+	Notes string `json:"notes"`
+}
+
+type RecordSessionExerciseRequest struct {
+	ActualReps   *int     `json:"actual_reps"`
+	ActualWeight *float64 `json:"actual_weight"`
+}
+
+type SessionSetRequest struct {
+	TargetReps   *int       `json:"target_reps"`
+	TargetWeight *float64   `json:"target_weight"`
+	ActualReps   *int       `json:"actual_reps"`
+	ActualWeight *float64   `json:"actual_weight"`
+	RPE          *float64   `json:"rpe"`
+	RIR          *int       `json:"rir"`
+	IsWarmup     *bool      `json:"is_warmup"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
+
+func (req SessionSetRequest) toInput() domain.SessionSetInput {
+	return domain.SessionSetInput{
+		TargetReps:   req.TargetReps,
+		TargetWeight: req.TargetWeight,
+		ActualReps:   req.ActualReps,
+		ActualWeight: req.ActualWeight,
+		RPE:          req.RPE,
+		RIR:          req.RIR,
+		IsWarmup:     req.IsWarmup,
+		CompletedAt:  req.CompletedAt,
+	}
+}
+
+func (h *Handler) Sessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+	if format != "" {
+		h.ExportSessions(w, r, userID, format)
+		return
+	}
+
+	p, err := parsePagination(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+	status := strings.TrimSpace(r.URL.Query().Get("status"))
+
+	res, err := h.sessionUsecase.GetSessions(r.Context(), userID, p, domain.WorkoutSessionFilter{Status: status})
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	data := make([]httperr.WorkoutSessionDTO, 0, len(res.Data))
+	for _, s := range res.Data {
+		data = append(data, httperr.ToWorkoutSessionDTO(s))
+	}
+
+	response.JSON(w, http.StatusOK, httperr.PaginatedResponse[httperr.WorkoutSessionDTO]{
+		Data: data,
+		Meta: httperr.PaginationMeta{Total: res.Total, Page: res.Page, Limit: res.Limit, TotalPages: res.TotalPages},
+	})
+}
+
+// ExportSessions renders every set of the finished sessions between the from
+// and to dates instead of a page of sessions.
+func (h *Handler) ExportSessions(w http.ResponseWriter, r *http.Request, userID string, format string) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	history, err := h.reportUsecase.SessionHistory(r.Context(), userID, from, to)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	h.writeExport(w, r, format, "sessions", httperr.SessionHistoryDocument(*history))
+}
+
+func (h *Handler) StartSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	var req StartSessionRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	s, err := h.sessionUsecase.StartSession(r.Context(), userID, req.WorkoutPlanID, req.Notes)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, httperr.ToWorkoutSessionDTO(*s))
+}
+
+func (h *Handler) SessionByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := GetUserIDFromContext(r.Context())
+	if !ok {
+		httperr.WriteError(w, r, h.logger, domain.ErrUnauthorized)
+		return
+	}
+
+	parts := pathSegments(r, "/api/sessions/")
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.GetSessionByID(w, r, userID, parts[0])
+	case len(parts) == 2 && parts[1] == "finish" && r.Method == http.MethodPost:
+		h.FinishSession(w, r, userID, parts[0])
+	case len(parts) == 2 && parts[1] == "exercises" && r.Method == http.MethodPost:
+		h.AddSessionExercise(w, r, userID, parts[0])
+	case len(parts) == 2 && parts[1] == "save-as-plan" && r.Method == http.MethodPost:
+		h.SaveSessionAsPlan(w, r, userID, parts[0])
+	case len(parts) == 3 && parts[1] == "exercises" && r.Method == http.MethodPut:
+		h.RecordSessionExercise(w, r, userID, parts[0], parts[2])
+	case len(parts) == 4 && parts[1] == "exercises" && parts[3] == "sets" && r.Method == http.MethodPost:
+		h.AddSessionSet(w, r, userID, parts[0], parts[2])
+	case len(parts) == 3 && parts[1] == "sets" && r.Method == http.MethodPut:
+		h.LogSessionSet(w, r, userID, parts[0], parts[2])
+	case len(parts) >= 1 && len(parts) <= 4:
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+	default:
+		httperr.WriteError(w, r, h.logger, domain.ErrNotFound)
+	}
+}
+
+func (h *Handler) GetSessionByID(w http.ResponseWriter, r *http.Request, userID string, sessionID string) {
+	s, err := h.sessionUsecase.GetSessionByID(r.Context(), userID, sessionID)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToWorkoutSessionDTO(*s))
+}
+
+func (h *Handler) FinishSession(w http.ResponseWriter, r *http.Request, userID string, sessionID string) {
+	var req FinishSessionRequest
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	s, records, err := h.sessionUsecase.FinishSession(r.Context(), userID, sessionID, req.Notes)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.FinishedSessionDTO{
+		WorkoutSessionDTO: httperr.ToWorkoutSessionDTO(*s),
+		PersonalRecords:   httperr.ToPersonalRecordDTOs(records),
+	})
+}
+
+func (h *Handler) RecordSessionExercise(w http.ResponseWriter, r *http.Request, userID string, sessionID string, sessionExerciseID string) {
+	var req RecordSessionExerciseRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	if err := h.sessionUsecase.RecordExercise(r.Context(), userID, sessionID, sessionExerciseID, req.ActualReps, req.ActualWeight); err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{"message": "recorded"})
+}
+
+func (h *Handler) AddSessionSet(w http.ResponseWriter, r *http.Request, userID string, sessionID string, sessionExerciseID string) {
+	var req SessionSetRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	st, err := h.sessionUsecase.AddSet(r.Context(), userID, sessionID, sessionExerciseID, req.toInput())
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, httperr.ToWorkoutSessionSetDTO(*st))
+}
+
+func (h *Handler) LogSessionSet(w http.ResponseWriter, r *http.Request, userID string, sessionID string, setID string) {
+	var req SessionSetRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	st, err := h.sessionUsecase.LogSet(r.Context(), userID, sessionID, setID, req.toInput())
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, httperr.ToWorkoutSessionSetDTO(*st))
+}
+
+func (h *Handler) AddSessionExercise(w http.ResponseWriter, r *http.Request, userID string, sessionID string) {
+	var req AddSessionExerciseRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	req.ExerciseID = strings.TrimSpace(req.ExerciseID)
+	if req.ExerciseID == "" {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	ex, err := h.sessionUsecase.AddExercise(r.Context(), userID, sessionID, req.ExerciseID, req.Sets, req.Reps, req.Weight)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, httperr.ToWorkoutSessionExerciseDTO(*ex))
+}
+
+func (h *Handler) SaveSessionAsPlan(w http.ResponseWriter, r *http.Request, userID string, sessionID string) {
+	var req SaveSessionAsPlanRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		httperr.WriteError(w, r, h.logger, domain.ErrInvalidInput)
+		return
+	}
+
+	plan, err := h.sessionUsecase.SaveAsPlan(r.Context(), userID, sessionID, req.Name, req.Notes)
+	if err != nil {
+		httperr.WriteError(w, r, h.logger, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, map[string]string{"message": "workout created", "id": plan.ID})
+}
@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+// AdherencePeriod compares what was scheduled in a period with what was
+// trained.
+type AdherencePeriod struct {
+	Start     time.Time
+	End       time.Time
+	Scheduled int
+	Completed int
+	Missed    int
+	Pending   int
+	Canceled  int
+	// Sessions counts finished sessions, scheduled or not.
+	Sessions int
+}
+
+// Rate is the percentage of resolved schedules that were completed, nil
+// while none is resolved. Pending and canceled schedules do not count.
+func (p AdherencePeriod) Rate() *float64 {
+	resolved := p.Completed + p.Missed
+	if resolved == 0 {
+		return nil
+	}
+	rate := float64(p.Completed) * 100 / float64(resolved)
+	return &rate
+}
+
+// Count tallies one schedule by status.
+func (p *AdherencePeriod) Count(status string) {
+	switch status {
+	case ScheduleStatusCompleted:
+		p.Completed++
+	case ScheduleStatusMissed:
+		p.Missed++
+	case ScheduleStatusPending:
+		p.Pending++
+	case ScheduleStatusCanceled:
+		p.Canceled++
+		return
+	}
+	p.Scheduled++
+}
+
+// Streak is a run of consecutive days or weeks with at least one finished
+// session.
+type Streak struct {
+	Current int
+	Longest int
+}
+
+// ComputeStreak measures the streaks in days, the local dates the user
+// trained on, by calendar day or week. The current streak survives until
+// the period after its last one has passed: training yesterday keeps a daily
+// streak alive today.
+func ComputeStreak(days []time.Time, today time.Time, granularity string) Streak {
+	var out Streak
+	var prev time.Time
+	run := 0
+	for _, d := range days {
+		p := CalendarPeriodStart(d, granularity)
+		switch {
+		case run > 0 && p.Equal(prev):
+			continue
+		case run > 0 && p.Equal(CalendarPeriodNext(prev, granularity)):
+			run++
+		default:
+			run = 1
+		}
+		prev = p
+		if run > out.Longest {
+			out.Longest = run
+		}
+	}
+
+	if run == 0 {
+		return out
+	}
+	current := CalendarPeriodStart(today, granularity)
+	if prev.Equal(current) || CalendarPeriodNext(prev, granularity).Equal(current) {
+		out.Current = run
+	}
+	return out
+}
+
+type AdherenceReport struct {
+	From         time.Time
+	To           time.Time
+	Timezone     string
+	Granularity  string
+	Totals       AdherencePeriod
+	Periods      []AdherencePeriod
+	DailyStreak  Streak
+	WeeklyStreak Streak
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeStreak(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	// 2026-03-02 and 2026-03-09 are Mondays.
+	days := []time.Time{day(2), day(3), day(4), day(9), day(16), day(17), day(17), day(18)}
+
+	tests := []struct {
+		name        string
+		today       time.Time
+		granularity string
+		want        Streak
+	}{
+		{name: "daily ongoing", today: day(18), granularity: CalendarDay, want: Streak{Current: 3, Longest: 3}},
+		{name: "daily kept by yesterday", today: day(19), granularity: CalendarDay, want: Streak{Current: 3, Longest: 3}},
+		{name: "daily broken", today: day(20), granularity: CalendarDay, want: Streak{Current: 0, Longest: 3}},
+		{name: "weekly", today: day(24), granularity: CalendarWeek, want: Streak{Current: 3, Longest: 3}},
+		{name: "weekly broken", today: day(30), granularity: CalendarWeek, want: Streak{Current: 0, Longest: 3}},
+	}
+
+	for _, tt := range tests {
+		if got := ComputeStreak(days, tt.today, tt.granularity); got != tt.want {
+			t.Fatalf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+
+	if got := ComputeStreak(nil, day(1), CalendarDay); got != (Streak{}) {
+		t.Fatalf("empty: expected no streak, got %+v", got)
+	}
+}
+
+func TestAdherencePeriod_Rate(t *testing.T) {
+	var p AdherencePeriod
+	if p.Rate() != nil {
+		t.Fatal("expected no rate without resolved schedules")
+	}
+
+	for _, s := range []string{ScheduleStatusCompleted, ScheduleStatusCompleted, ScheduleStatusCompleted, ScheduleStatusMissed, ScheduleStatusPending, ScheduleStatusCanceled} {
+		p.Count(s)
+	}
+	if p.Scheduled != 5 || p.Canceled != 1 {
+		t.Fatalf("unexpected counts %+v", p)
+	}
+	if got := p.Rate(); got == nil || *got != 75 {
+		t.Fatalf("expected 75%%, got %v", got)
+	}
+}
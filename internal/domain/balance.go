@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// UnspecifiedGroup labels work on exercises without a category or muscle
+// group.
+const UnspecifiedGroup = "other"
+
+// TrainingLoad counts working sets and their volume.
+type TrainingLoad struct {
+	Sets   int
+	Volume float64
+}
+
+func (l TrainingLoad) Add(o TrainingLoad) TrainingLoad {
+	return TrainingLoad{Sets: l.Sets + o.Sets, Volume: l.Volume + o.Volume}
+}
+
+// MuscleWork is the load of one exercise over the finished sessions of a
+// calendar day.
+type MuscleWork struct {
+	Day         time.Time
+	Category    string
+	MuscleGroup string
+	TrainingLoad
+}
+
+// BalanceRule compares the sets done on two sets of muscle groups. A ratio
+// of numerator to denominator sets outside [Min, Max] is an imbalance.
+type BalanceRule struct {
+	Name        string
+	Numerator   []string
+	Denominator []string
+	Min         float64
+	Max         float64
+}
+
+// BalanceRules are the ratios coaches check by default: pressing against
+// pulling and upper against lower body.
+var BalanceRules = []BalanceRule{
+	{Name: "push_pull", Numerator: []string{"chest", "shoulders"}, Denominator: []string{"back"}, Min: 2.0 / 3, Max: 1.5},
+	{Name: "upper_lower", Numerator: []string{"chest", "back", "shoulders", "arms"}, Denominator: []string{"legs"}, Min: 0.5, Max: 2},
+}
+
+type BalanceRatio struct {
+	Rule            BalanceRule
+	NumeratorSets   int
+	DenominatorSets int
+	// Ratio is nil when the denominator has no sets.
+	Ratio      *float64
+	Imbalanced bool
+}
+
+// Evaluate applies the rule to sets per muscle group. Training only one
+// side counts as imbalanced; training neither does not.
+func (r BalanceRule) Evaluate(groups map[string]TrainingLoad) BalanceRatio {
+	out := BalanceRatio{Rule: r}
+	for _, g := range r.Numerator {
+		out.NumeratorSets += groups[g].Sets
+	}
+	for _, g := range r.Denominator {
+		out.DenominatorSets += groups[g].Sets
+	}
+
+	if out.DenominatorSets == 0 {
+		out.Imbalanced = out.NumeratorSets > 0
+		return out
+	}
+
+	ratio := float64(out.NumeratorSets) / float64(out.DenominatorSets)
+	out.Ratio = &ratio
+	out.Imbalanced = ratio < r.Min || ratio > r.Max
+	return out
+}
+
+type WeeklyBalance struct {
+	WeekStart    time.Time
+	MuscleGroups map[string]TrainingLoad
+	Categories   map[string]TrainingLoad
+}
+
+// BalanceReport spreads working sets over muscle groups and categories for
+// the calendar days From through To in Timezone.
+type BalanceReport struct {
+	From         time.Time
+	To           time.Time
+	Timezone     string
+	MuscleGroups map[string]TrainingLoad
+	Categories   map[string]TrainingLoad
+	Weeks        []WeeklyBalance
+	Ratios       []BalanceRatio
+	// Neglected lists known muscle groups with no sets in the range.
+	Neglected []string
+}
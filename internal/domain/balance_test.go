@@ -0,0 +1,36 @@
+package domain
+
+import "testing"
+
+func TestBalanceRule_Evaluate(t *testing.T) {
+	rule := BalanceRule{Name: "push_pull", Numerator: []string{"chest", "shoulders"}, Denominator: []string{"back"}, Min: 2.0 / 3, Max: 1.5}
+
+	tests := []struct {
+		name       string
+		groups     map[string]TrainingLoad
+		ratio      float64
+		noRatio    bool
+		imbalanced bool
+	}{
+		{name: "balanced", groups: map[string]TrainingLoad{"chest": {Sets: 6}, "shoulders": {Sets: 4}, "back": {Sets: 8}}, ratio: 1.25},
+		{name: "too much push", groups: map[string]TrainingLoad{"chest": {Sets: 12}, "back": {Sets: 4}}, ratio: 3, imbalanced: true},
+		{name: "only push", groups: map[string]TrainingLoad{"chest": {Sets: 3}}, noRatio: true, imbalanced: true},
+		{name: "neither", groups: map[string]TrainingLoad{"legs": {Sets: 3}}, noRatio: true},
+	}
+
+	for _, tt := range tests {
+		got := rule.Evaluate(tt.groups)
+		if got.Imbalanced != tt.imbalanced {
+			t.Fatalf("%s: expected imbalanced %v, got %v", tt.name, tt.imbalanced, got.Imbalanced)
+		}
+		if tt.noRatio {
+			if got.Ratio != nil {
+				t.Fatalf("%s: expected no ratio, got %v", tt.name, *got.Ratio)
+			}
+			continue
+		}
+		if got.Ratio == nil || *got.Ratio != tt.ratio {
+			t.Fatalf("%s: expected ratio %v, got %v", tt.name, tt.ratio, got.Ratio)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "time"
+
+// CalendarEntry is a scheduled workout enriched with what calendar clients
+// need to describe it.
+type CalendarEntry struct {
+	Schedule  ScheduledWorkout
+	PlanName  string
+	Exercises []CalendarExercise
+}
+
+type CalendarExercise struct {
+	Name   string
+	Sets   int
+	Reps   int
+	Weight float64
+}
+
+const (
+	CalendarDay   = "day"
+	CalendarWeek  = "week"
+	CalendarMonth = "month"
+)
+
+func IsValidCalendarGranularity(g string) bool {
+	switch g {
+	case CalendarDay, CalendarWeek, CalendarMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// CalendarView is a range of the user's calendar split into buckets of one
+// day, week or month, evaluated in Timezone.
+type CalendarView struct {
+	Timezone    string
+	Granularity string
+	From        time.Time
+	To          time.Time
+	Buckets     []CalendarBucket
+}
+
+// CalendarBucket holds what happened in one period. Start and End are the
+// first and last calendar day of the period.
+type CalendarBucket struct {
+	Start        time.Time
+	End          time.Time
+	Schedules    []AgendaItem
+	Sessions     []WorkoutSession
+	StatusCounts map[string]int
+}
+
+// Status summarizes the bucket by the schedule status that most needs the
+// user's attention: missed, then pending, completed and canceled. A bucket
+// with finished sessions but no schedules counts as completed, and an empty
+// bucket has no status.
+func (b CalendarBucket) Status() string {
+	for _, s := range []string{ScheduleStatusMissed, ScheduleStatusPending, ScheduleStatusCompleted, ScheduleStatusCanceled} {
+		if b.StatusCounts[s] > 0 {
+			return s
+		}
+	}
+	if len(b.Sessions) > 0 {
+		return ScheduleStatusCompleted
+	}
+	return ""
+}
+
+// CalendarPeriodStart returns the first day of the period containing the
+// civil date d. Weeks start on Monday.
+func CalendarPeriodStart(d time.Time, granularity string) time.Time {
+	d = CivilDate(d)
+	switch granularity {
+	case CalendarWeek:
+		offset := (int(d.Weekday()) + 6) % 7
+		return d.AddDate(0, 0, -offset)
+	case CalendarMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return d
+	}
+}
+
+// CalendarPeriodNext returns the first day of the period after the one
+// starting at start.
+func CalendarPeriodNext(start time.Time, granularity string) time.Time {
+	switch granularity {
+	case CalendarWeek:
+		return start.AddDate(0, 0, 7)
+	case CalendarMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarPeriodStart(t *testing.T) {
+	// 2026-03-15 is a Sunday.
+	d := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	if got := CalendarPeriodStart(d, CalendarDay); !got.Equal(d) {
+		t.Fatalf("day: expected %v, got %v", d, got)
+	}
+	if got, want := CalendarPeriodStart(d, CalendarWeek), time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("week: expected %v, got %v", want, got)
+	}
+	if got, want := CalendarPeriodStart(d, CalendarMonth), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("month: expected %v, got %v", want, got)
+	}
+
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	if got := CalendarPeriodStart(monday, CalendarWeek); !got.Equal(monday) {
+		t.Fatalf("week from monday: expected %v, got %v", monday, got)
+	}
+}
+
+func TestCalendarBucket_Status(t *testing.T) {
+	b := CalendarBucket{StatusCounts: map[string]int{ScheduleStatusCompleted: 2, ScheduleStatusPending: 1}}
+	if got := b.Status(); got != ScheduleStatusPending {
+		t.Fatalf("expected pending, got %q", got)
+	}
+
+	b = CalendarBucket{StatusCounts: map[string]int{}, Sessions: []WorkoutSession{{ID: "s1"}}}
+	if got := b.Status(); got != ScheduleStatusCompleted {
+		t.Fatalf("expected completed, got %q", got)
+	}
+
+	if got := (CalendarBucket{}).Status(); got != "" {
+		t.Fatalf("expected empty status, got %q", got)
+	}
+}
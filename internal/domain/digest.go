@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const MailKindWeeklyDigest = "weekly_digest"
+
+// DigestRecipient is a user due the digest of the week starting WeekStart, a
+// Monday in Timezone.
+type DigestRecipient struct {
+	UserID    string
+	Name      string
+	Email     string
+	Timezone  string
+	WeekStart time.Time
+}
+
+func (r DigestRecipient) WeekEnd() time.Time {
+	return r.WeekStart.AddDate(0, 0, 6)
+}
+
+// WeeklyDigest summarises a user's last week and lists the workouts scheduled
+// for the week that follows it.
+type WeeklyDigest struct {
+	DigestRecipient
+	TrainingTotals
+	PreviousVolume float64
+	Records        []PersonalRecord
+	Upcoming       []AgendaItem
+}
+
+// VolumeChange is the change in volume from the week before, in percent. It
+// is nil when nothing was lifted that week.
+func (d WeeklyDigest) VolumeChange() *float64 {
+	if d.PreviousVolume <= 0 {
+		return nil
+	}
+	v := math.Round((d.Volume-d.PreviousVolume)/d.PreviousVolume*1000) / 10
+	return &v
+}
+
+// DigestRenderer turns a digest into an email.
+type DigestRenderer interface {
+	RenderDigest(d WeeklyDigest) (MailContent, error)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestWeeklyDigest_VolumeChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		volume   float64
+		previous float64
+		want     *float64
+	}{
+		{name: "increase", volume: 5500, previous: 5000, want: floatPtr(10)},
+		{name: "decrease", volume: 2000, previous: 3000, want: floatPtr(-33.3)},
+		{name: "no previous week", volume: 2000, previous: 0, want: nil},
+	}
+
+	for _, tt := range tests {
+		d := WeeklyDigest{TrainingTotals: TrainingTotals{Volume: tt.volume}, PreviousVolume: tt.previous}
+		got := d.VolumeChange()
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,81 @@
+package domain
+
+import "sort"
+
+const (
+	GroupSuperset = "superset"
+	GroupCircuit  = "circuit"
+	GroupGiantSet = "giant_set"
+)
+
+// ExerciseGroup links plan exercises performed back to back, such as the
+// A1/A2 pair of a superset. Every member carries the same group.
+type ExerciseGroup struct {
+	// ID labels the group within its plan, e.g. "A".
+	ID   string
+	Type string
+	// Rounds is how often the group is gone through.
+	Rounds int
+	// RestSeconds is the rest after each round.
+	RestSeconds int
+}
+
+// memberRange returns how many exercises a group of this type holds.
+func (g ExerciseGroup) memberRange() (lo, hi int, ok bool) {
+	switch g.Type {
+	case GroupSuperset:
+		return 2, 2, true
+	case GroupGiantSet:
+		return 3, 0, true
+	case GroupCircuit:
+		return 2, 0, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// ValidateExerciseGroups checks the groups of a plan's exercises: each group
+// has a known type, at least one round and no negative rest, its members
+// agree on those settings, sit next to each other in order and number what
+// the type allows (two for a superset, three or more for a giant set, two or
+// more for a circuit).
+func ValidateExerciseGroups(exercises []WorkoutPlanExercise) error {
+	ordered := make([]WorkoutPlanExercise, len(exercises))
+	copy(ordered, exercises)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].OrderIndex < ordered[j].OrderIndex })
+
+	groups := make(map[string]ExerciseGroup)
+	members := make(map[string]int)
+	prev := ""
+	for _, ex := range ordered {
+		if ex.Group == nil {
+			prev = ""
+			continue
+		}
+		g := *ex.Group
+		if g.ID == "" || g.Rounds < 1 || g.RestSeconds < 0 {
+			return ErrInvalidInput
+		}
+		if _, _, ok := g.memberRange(); !ok {
+			return ErrInvalidInput
+		}
+
+		if seen, ok := groups[g.ID]; ok {
+			if seen != g || prev != g.ID {
+				return ErrInvalidInput
+			}
+		}
+		groups[g.ID] = g
+		members[g.ID]++
+		prev = g.ID
+	}
+
+	for id, g := range groups {
+		lo, hi, _ := g.memberRange()
+		if members[id] < lo || (hi > 0 && members[id] > hi) {
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateExerciseGroups(t *testing.T) {
+	group := func(id, typ string, rounds, rest int) *ExerciseGroup {
+		return &ExerciseGroup{ID: id, Type: typ, Rounds: rounds, RestSeconds: rest}
+	}
+	ex := func(order int, g *ExerciseGroup) WorkoutPlanExercise {
+		return WorkoutPlanExercise{ExerciseID: "e", Sets: 3, Reps: 10, OrderIndex: order, Group: g}
+	}
+	superset := group("A", GroupSuperset, 3, 90)
+
+	tests := []struct {
+		name      string
+		exercises []WorkoutPlanExercise
+		wantErr   bool
+	}{
+		{name: "no groups", exercises: []WorkoutPlanExercise{ex(0, nil), ex(1, nil)}},
+		{name: "superset", exercises: []WorkoutPlanExercise{ex(0, nil), ex(1, superset), ex(2, group("A", GroupSuperset, 3, 90))}},
+		{name: "members given out of order", exercises: []WorkoutPlanExercise{ex(2, superset), ex(0, nil), ex(1, superset)}},
+		{name: "circuit and giant set", exercises: []WorkoutPlanExercise{
+			ex(0, group("A", GroupCircuit, 2, 0)), ex(1, group("A", GroupCircuit, 2, 0)),
+			ex(2, group("B", GroupGiantSet, 4, 120)), ex(3, group("B", GroupGiantSet, 4, 120)), ex(4, group("B", GroupGiantSet, 4, 120)),
+		}},
+		{name: "superset of three", exercises: []WorkoutPlanExercise{ex(0, superset), ex(1, superset), ex(2, superset)}, wantErr: true},
+		{name: "giant set of two", exercises: []WorkoutPlanExercise{ex(0, group("A", GroupGiantSet, 3, 60)), ex(1, group("A", GroupGiantSet, 3, 60))}, wantErr: true},
+		{name: "single member", exercises: []WorkoutPlanExercise{ex(0, group("A", GroupCircuit, 3, 60)), ex(1, nil)}, wantErr: true},
+		{name: "members not adjacent", exercises: []WorkoutPlanExercise{ex(0, superset), ex(1, nil), ex(2, superset)}, wantErr: true},
+		{name: "members disagree", exercises: []WorkoutPlanExercise{ex(0, superset), ex(1, group("A", GroupSuperset, 4, 90))}, wantErr: true},
+		{name: "unknown type", exercises: []WorkoutPlanExercise{ex(0, group("A", "dropset", 3, 60)), ex(1, group("A", "dropset", 3, 60))}, wantErr: true},
+		{name: "no rounds", exercises: []WorkoutPlanExercise{ex(0, group("A", GroupSuperset, 0, 60)), ex(1, group("A", GroupSuperset, 0, 60))}, wantErr: true},
+		{name: "negative rest", exercises: []WorkoutPlanExercise{ex(0, group("A", GroupSuperset, 3, -1)), ex(1, group("A", GroupSuperset, 3, -1))}, wantErr: true},
+		{name: "missing id", exercises: []WorkoutPlanExercise{ex(0, group("", GroupSuperset, 3, 60)), ex(1, group("", GroupSuperset, 3, 60))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateExerciseGroups(tt.exercises)
+		if tt.wantErr && !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("%s: expected invalid input, got %v", tt.name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+	}
+}
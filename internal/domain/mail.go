@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	MailStatusPending = "pending"
+	// MailStatusSending marks a claimed message; it is retried when the
+	// claim expires without the message being sent.
+	MailStatusSending = "sending"
+	MailStatusSent    = "sent"
+	MailStatusFailed  = "failed"
+)
+
+// MailContent is a rendered email.
+type MailContent struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// MailMessage is an email in the outbox. Kind, UserID and PeriodStart
+// identify it, so each kind of mail is queued at most once per period.
+type MailMessage struct {
+	ID          string
+	UserID      string
+	Kind        string
+	PeriodStart time.Time
+	ToName      string
+	ToAddress   string
+	MailContent
+	Status        string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
+
+// MailTransport delivers outbox messages.
+type MailTransport interface {
+	Send(ctx context.Context, m MailMessage) error
+}
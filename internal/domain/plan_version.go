@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+)
+
+// WorkoutPlanVersion is an immutable snapshot of a plan, taken whenever the
+// plan is created, updated or restored. Versions are numbered from 1 per plan.
+type WorkoutPlanVersion struct {
+	ID            string
+	WorkoutPlanID string
+	Version       int
+	Name          string
+	Notes         string
+	// RestoredFrom is the version this one was restored from, if any.
+	RestoredFrom *int
+	CreatedAt    time.Time
+	Exercises    []WorkoutPlanExerciseDetail
+}
+
+// Fields compared between two versions of a plan exercise.
+const (
+	PlanFieldSets             = "sets"
+	PlanFieldReps             = "reps"
+	PlanFieldWeight           = "weight"
+	PlanFieldOrderIndex       = "order_index"
+	PlanFieldGroup            = "group"
+	PlanFieldTrainingMax      = "training_max"
+	PlanFieldSetPrescriptions = "set_prescriptions"
+)
+
+// PlanExerciseChange is an exercise present in both versions of a diff whose
+// prescription changed.
+type PlanExerciseChange struct {
+	Before WorkoutPlanExerciseDetail
+	After  WorkoutPlanExerciseDetail
+	Fields []string
+}
+
+// PlanVersionDiff describes how a plan changed from one version to another.
+type PlanVersionDiff struct {
+	From         int
+	To           int
+	NameChanged  bool
+	NotesChanged bool
+	Added        []WorkoutPlanExerciseDetail
+	Removed      []WorkoutPlanExerciseDetail
+	Changed      []PlanExerciseChange
+}
+
+// DiffPlanVersions compares two versions of a plan. Exercises are matched by
+// exercise ID; when a plan lists an exercise more than once, occurrences are
+// matched in order.
+func DiffPlanVersions(from, to WorkoutPlanVersion) PlanVersionDiff {
+	diff := PlanVersionDiff{
+		From:         from.Version,
+		To:           to.Version,
+		NameChanged:  from.Name != to.Name,
+		NotesChanged: from.Notes != to.Notes,
+		Added:        make([]WorkoutPlanExerciseDetail, 0),
+		Removed:      make([]WorkoutPlanExerciseDetail, 0),
+		Changed:      make([]PlanExerciseChange, 0),
+	}
+
+	before := make(map[string][]WorkoutPlanExerciseDetail)
+	for _, ex := range from.Exercises {
+		before[ex.ExerciseID] = append(before[ex.ExerciseID], ex)
+	}
+
+	for _, after := range to.Exercises {
+		matches := before[after.ExerciseID]
+		if len(matches) == 0 {
+			diff.Added = append(diff.Added, after)
+			continue
+		}
+		prev := matches[0]
+		before[after.ExerciseID] = matches[1:]
+
+		if fields := changedPlanFields(prev, after); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, PlanExerciseChange{Before: prev, After: after, Fields: fields})
+		}
+	}
+
+	for _, ex := range from.Exercises {
+		if rest := before[ex.ExerciseID]; len(rest) > 0 {
+			diff.Removed = append(diff.Removed, rest...)
+			delete(before, ex.ExerciseID)
+		}
+	}
+
+	return diff
+}
+
+func changedPlanFields(a, b WorkoutPlanExerciseDetail) []string {
+	var fields []string
+	if a.Sets != b.Sets {
+		fields = append(fields, PlanFieldSets)
+	}
+	if a.Reps != b.Reps {
+		fields = append(fields, PlanFieldReps)
+	}
+	if a.Weight != b.Weight {
+		fields = append(fields, PlanFieldWeight)
+	}
+	if a.OrderIndex != b.OrderIndex {
+		fields = append(fields, PlanFieldOrderIndex)
+	}
+	if (a.Group == nil) != (b.Group == nil) || (a.Group != nil && *a.Group != *b.Group) {
+		fields = append(fields, PlanFieldGroup)
+	}
+	if !reflect.DeepEqual(a.TrainingMax, b.TrainingMax) {
+		fields = append(fields, PlanFieldTrainingMax)
+	}
+	if len(a.SetPrescriptions) != 0 || len(b.SetPrescriptions) != 0 {
+		if !reflect.DeepEqual(a.SetPrescriptions, b.SetPrescriptions) {
+			fields = append(fields, PlanFieldSetPrescriptions)
+		}
+	}
+	return fields
+}
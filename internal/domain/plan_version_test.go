@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffPlanVersions(t *testing.T) {
+	ex := func(exerciseID string, sets, reps int, weight float64, order int) WorkoutPlanExerciseDetail {
+		return WorkoutPlanExerciseDetail{WorkoutPlanExercise: WorkoutPlanExercise{ExerciseID: exerciseID, Sets: sets, Reps: reps, Weight: weight, OrderIndex: order}}
+	}
+
+	from := WorkoutPlanVersion{
+		Version: 1,
+		Name:    "Push Day",
+		Exercises: []WorkoutPlanExerciseDetail{
+			ex("bench", 3, 8, 60, 0),
+			ex("dips", 3, 10, 0, 1),
+			ex("fly", 3, 12, 10, 2),
+			ex("fly", 2, 15, 8, 3),
+		},
+	}
+	to := WorkoutPlanVersion{
+		Version: 3,
+		Name:    "Push Day",
+		Notes:   "heavier",
+		Exercises: []WorkoutPlanExerciseDetail{
+			ex("bench", 5, 5, 70, 0),
+			ex("fly", 3, 12, 10, 1),
+			ex("press", 3, 8, 30, 2),
+		},
+	}
+
+	diff := DiffPlanVersions(from, to)
+
+	if diff.From != 1 || diff.To != 3 {
+		t.Fatalf("expected versions 1 to 3, got %d to %d", diff.From, diff.To)
+	}
+	if diff.NameChanged || !diff.NotesChanged {
+		t.Fatalf("expected only notes to change, got name %v notes %v", diff.NameChanged, diff.NotesChanged)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].ExerciseID != "press" {
+		t.Fatalf("expected press added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 2 || diff.Removed[0].ExerciseID != "dips" || diff.Removed[1].ExerciseID != "fly" || diff.Removed[1].Sets != 2 {
+		t.Fatalf("expected dips and the second fly removed, got %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 2 {
+		t.Fatalf("expected 2 changed exercises, got %+v", diff.Changed)
+	}
+	if want := []string{PlanFieldSets, PlanFieldReps, PlanFieldWeight}; !reflect.DeepEqual(diff.Changed[0].Fields, want) {
+		t.Fatalf("expected bench fields %v, got %v", want, diff.Changed[0].Fields)
+	}
+	if want := []string{PlanFieldOrderIndex}; diff.Changed[1].After.ExerciseID != "fly" || !reflect.DeepEqual(diff.Changed[1].Fields, want) {
+		t.Fatalf("expected fly to move, got %+v", diff.Changed[1])
+	}
+}
+
+func TestDiffPlanVersions_Identical(t *testing.T) {
+	v := WorkoutPlanVersion{
+		Version:   2,
+		Name:      "Legs",
+		Exercises: []WorkoutPlanExerciseDetail{{WorkoutPlanExercise: WorkoutPlanExercise{ExerciseID: "squat", Sets: 5, Reps: 5, Weight: 100}}},
+	}
+
+	diff := DiffPlanVersions(v, v)
+	if diff.NameChanged || diff.NotesChanged || len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Fatalf("expected no changes, got %+v", diff)
+	}
+}
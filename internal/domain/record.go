@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	RecordMaxWeight = "max_weight"
+	// RecordMaxReps is the most reps done at one weight; each weight keeps
+	// its own record.
+	RecordMaxReps       = "max_reps"
+	RecordOneRepMax     = "estimated_1rm"
+	RecordSessionVolume = "session_volume"
+)
+
+type PersonalRecord struct {
+	ID           string
+	UserID       string
+	ExerciseID   string
+	ExerciseName string
+	SessionID    string
+	Type         string
+	Value        float64
+	// Previous is the record this one beat, nil when it was the first.
+	Previous   *float64
+	Weight     float64
+	Reps       int
+	AchievedAt time.Time
+}
+
+// Key identifies the record a value competes with.
+func (r PersonalRecord) Key() string {
+	key := r.ExerciseID + "|" + r.Type
+	if r.Type == RecordMaxReps {
+		key += "|" + strconv.FormatFloat(r.Weight, 'f', 2, 64)
+	}
+	return key
+}
+
+// RecordBook holds the standing records and every record ever set.
+type RecordBook struct {
+	Current []PersonalRecord
+	History []PersonalRecord
+}
+
+// DetectRecords compares a finished session with the user's standing records
+// and returns the ones it beats, ordered by exercise and type. Only completed
+// working sets count; an exercise recorded without sets counts as its sets
+// at the recorded reps and weight. The estimated 1RM uses Epley.
+func DetectRecords(s WorkoutSession, current []PersonalRecord) []PersonalRecord {
+	best := make(map[string]float64, len(current))
+	for _, r := range current {
+		if v, ok := best[r.Key()]; !ok || r.Value > v {
+			best[r.Key()] = r.Value
+		}
+	}
+
+	order := []string{}
+	byExercise := map[string][]PerformedSet{}
+	for _, ex := range s.Exercises {
+		if _, ok := byExercise[ex.ExerciseID]; !ok {
+			order = append(order, ex.ExerciseID)
+		}
+		byExercise[ex.ExerciseID] = append(byExercise[ex.ExerciseID], workingSets(ex)...)
+	}
+
+	completedAt := s.StartedAt
+	if s.CompletedAt != nil {
+		completedAt = *s.CompletedAt
+	}
+
+	var out []PersonalRecord
+	for _, exerciseID := range order {
+		sets := byExercise[exerciseID]
+		if len(sets) == 0 {
+			continue
+		}
+
+		candidates := []PersonalRecord{}
+		var top, strongest *PerformedSet
+		var volume, topRM float64
+		repsAt := map[float64]int{}
+		for i := range sets {
+			st := &sets[i]
+			volume += float64(st.Reps) * st.Weight
+			if top == nil || st.Weight > top.Weight || (st.Weight == top.Weight && st.Reps > top.Reps) {
+				top = st
+			}
+			if rm := roundRecord(EstimateOneRepMax(OneRepMaxEpley, st.Weight, st.Reps)); rm > topRM {
+				topRM = rm
+				strongest = st
+			}
+			if st.Reps > repsAt[st.Weight] {
+				repsAt[st.Weight] = st.Reps
+			}
+		}
+
+		if top.Weight > 0 {
+			candidates = append(candidates, PersonalRecord{Type: RecordMaxWeight, Value: top.Weight, Weight: top.Weight, Reps: top.Reps})
+		}
+		weights := make([]float64, 0, len(repsAt))
+		for w := range repsAt {
+			weights = append(weights, w)
+		}
+		sort.Float64s(weights)
+		for _, w := range weights {
+			candidates = append(candidates, PersonalRecord{Type: RecordMaxReps, Value: float64(repsAt[w]), Weight: w, Reps: repsAt[w]})
+		}
+		if strongest != nil {
+			candidates = append(candidates, PersonalRecord{Type: RecordOneRepMax, Value: topRM, Weight: strongest.Weight, Reps: strongest.Reps})
+		}
+		if volume > 0 {
+			candidates = append(candidates, PersonalRecord{Type: RecordSessionVolume, Value: roundRecord(volume)})
+		}
+
+		for _, c := range candidates {
+			c.UserID = s.UserID
+			c.ExerciseID = exerciseID
+			c.SessionID = s.ID
+			c.AchievedAt = completedAt
+			if prev, ok := best[c.Key()]; ok {
+				if c.Value <= prev {
+					continue
+				}
+				c.Previous = &prev
+			}
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+func workingSets(ex WorkoutSessionExercise) []PerformedSet {
+	var out []PerformedSet
+	for _, st := range ex.SetLogs {
+		if st.IsWarmup || !st.IsCompleted() || st.ActualReps == nil || *st.ActualReps <= 0 {
+			continue
+		}
+		w := 0.0
+		switch {
+		case st.ActualWeight != nil:
+			w = *st.ActualWeight
+		case st.TargetWeight != nil:
+			w = *st.TargetWeight
+		}
+		out = append(out, PerformedSet{Reps: *st.ActualReps, Weight: w})
+	}
+	if len(out) > 0 || ex.ActualReps == nil || *ex.ActualReps <= 0 {
+		return out
+	}
+
+	w := ex.Weight
+	if ex.ActualWeight != nil {
+		w = *ex.ActualWeight
+	}
+	for i := 0; i < ex.Sets; i++ {
+		out = append(out, PerformedSet{Reps: *ex.ActualReps, Weight: w})
+	}
+	return out
+}
+
+func roundRecord(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetectRecords(t *testing.T) {
+	intp := func(v int) *int { return &v }
+	floatp := func(v float64) *float64 { return &v }
+	done := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+
+	s := WorkoutSession{
+		ID: "s1", UserID: "u1", StartedAt: done.Add(-time.Hour), CompletedAt: &done,
+		Exercises: []WorkoutSessionExercise{
+			{ExerciseID: "squat", SetLogs: []WorkoutSessionSet{
+				{ActualReps: intp(5), ActualWeight: floatp(60), IsWarmup: true, CompletedAt: &done},
+				{ActualReps: intp(5), ActualWeight: floatp(100), CompletedAt: &done},
+				{ActualReps: intp(3), ActualWeight: floatp(110), CompletedAt: &done},
+				{ActualReps: intp(8), TargetWeight: floatp(100)},
+			}},
+			// Recorded without sets: 3 × 12 at bodyweight.
+			{ExerciseID: "pullup", Sets: 3, Reps: 10, ActualReps: intp(12)},
+		},
+	}
+
+	current := []PersonalRecord{
+		{ExerciseID: "squat", Type: RecordMaxWeight, Value: 110},
+		{ExerciseID: "squat", Type: RecordMaxReps, Weight: 100, Value: 6},
+		{ExerciseID: "squat", Type: RecordOneRepMax, Value: 125},
+		{ExerciseID: "squat", Type: RecordSessionVolume, Value: 500},
+	}
+
+	got := DetectRecords(s, current)
+
+	want := []struct {
+		exercise string
+		typ      string
+		value    float64
+		previous *float64
+	}{
+		{"squat", RecordMaxReps, 3, nil},
+		{"squat", RecordSessionVolume, 830, floatp(500)},
+		{"pullup", RecordMaxReps, 12, nil},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d records, got %d: %+v", len(want), len(got), got)
+	}
+	for i, w := range want {
+		r := got[i]
+		if r.ExerciseID != w.exercise || r.Type != w.typ || r.Value != w.value {
+			t.Fatalf("record %d: expected %s %s %v, got %s %s %v", i, w.exercise, w.typ, w.value, r.ExerciseID, r.Type, r.Value)
+		}
+		if (w.previous == nil) != (r.Previous == nil) || (w.previous != nil && *w.previous != *r.Previous) {
+			t.Fatalf("record %d: unexpected previous %v", i, r.Previous)
+		}
+		if r.SessionID != "s1" || r.UserID != "u1" || !r.AchievedAt.Equal(done) {
+			t.Fatalf("record %d: unexpected metadata %+v", i, r)
+		}
+	}
+}
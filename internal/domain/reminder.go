@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxReminderLeadMinutes is the longest reminder lead time, one week.
+const MaxReminderLeadMinutes = 7 * 24 * 60
+
+// Reminder is a pending scheduled workout that is due to be announced to
+// its owner.
+type Reminder struct {
+	ScheduleID    string
+	UserID        string
+	UserName      string
+	Email         string
+	WorkoutPlanID string
+	PlanName      string
+	ScheduledAt   time.Time
+	Timezone      string
+	AllDay        bool
+	// Attempts counts earlier failed deliveries on the channel.
+	Attempts int
+}
+
+func (r Reminder) Location() *time.Location {
+	loc, err := LoadTimezone(r.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Notifier delivers reminders over one channel. Deliveries are recorded per
+// channel, so the name must be stable across restarts.
+type Notifier interface {
+	Channel() string
+	Notify(ctx context.Context, r Reminder) error
+}
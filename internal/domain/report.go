@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// DailyStats is what the finished sessions started on one calendar day
+// contribute to reports.
+type DailyStats struct {
+	Day      time.Time
+	Sessions int
+	Duration time.Duration
+	// Volume is reps × weight over completed working sets, or sets × reps ×
+	// weight of the exercise when no set was logged.
+	Volume      float64
+	ExerciseIDs []string
+}
+
+// TrainingTotals aggregates a set of sessions.
+type TrainingTotals struct {
+	Sessions          int
+	Duration          time.Duration
+	Volume            float64
+	DistinctExercises int
+}
+
+type WeeklySummary struct {
+	WeekStart time.Time
+	TrainingTotals
+}
+
+// ReportSummary covers the calendar days From through To in Timezone.
+type ReportSummary struct {
+	From     time.Time
+	To       time.Time
+	Timezone string
+	TrainingTotals
+	Weeks []WeeklySummary
+}
+
+const (
+	OneRepMaxEpley    = "epley"
+	OneRepMaxBrzycki  = "brzycki"
+	OneRepMaxLombardi = "lombardi"
+)
+
+func IsValidOneRepMaxFormula(formula string) bool {
+	switch formula {
+	case OneRepMaxEpley, OneRepMaxBrzycki, OneRepMaxLombardi:
+		return true
+	default:
+		return false
+	}
+}
+
+// EstimateOneRepMax predicts the heaviest single from a set of reps at
+// weight. A single is its own max; Brzycki is undefined from 37 reps on and
+// yields 0 there.
+func EstimateOneRepMax(formula string, weight float64, reps int) float64 {
+	if reps <= 0 || weight <= 0 {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+
+	switch formula {
+	case OneRepMaxBrzycki:
+		if reps >= 37 {
+			return 0
+		}
+		return weight * 36 / float64(37-reps)
+	case OneRepMaxLombardi:
+		return weight * math.Pow(float64(reps), 0.10)
+	default:
+		return weight * (1 + float64(reps)/30)
+	}
+}
+
+// PerformedSet is one working set of an exercise within a finished session.
+type PerformedSet struct {
+	SessionID   string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	Reps        int
+	Weight      float64
+}
+
+// ProgressPoint summarises an exercise over one session, or over a week or
+// month when the report is aggregated.
+type ProgressPoint struct {
+	Start     time.Time
+	SessionID string
+	Sessions  int
+	// BestSet is the set with the highest estimated one-rep max.
+	BestSet   PerformedSet
+	TopWeight float64
+	Volume    float64
+	OneRepMax float64
+}
+
+// ProgressPerSession reports one point per session; weeks and months use
+// the calendar granularities.
+const ProgressPerSession = "session"
+
+type ProgressReport struct {
+	Exercise    Exercise
+	Formula     string
+	Granularity string
+	Timezone    string
+	Points      []ProgressPoint
+}
+
+// SessionLogEntry is one set of a finished session. Exercises recorded
+// without sets contribute their prescribed sets; sessions without exercises
+// have a single entry with no exercise.
+type SessionLogEntry struct {
+	SessionID    string
+	PlanName     string
+	StartedAt    time.Time
+	CompletedAt  time.Time
+	ExerciseName string
+	SetNumber    int
+	Reps         int
+	Weight       float64
+	IsWarmup     bool
+	RPE          *float64
+}
+
+type SessionHistory struct {
+	Timezone string
+	From     *time.Time
+	To       *time.Time
+	Entries  []SessionLogEntry
+}
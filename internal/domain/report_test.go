@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateOneRepMax(t *testing.T) {
+	tests := []struct {
+		formula string
+		weight  float64
+		reps    int
+		want    float64
+	}{
+		{OneRepMaxEpley, 100, 5, 116.667},
+		{OneRepMaxBrzycki, 100, 5, 112.5},
+		{OneRepMaxLombardi, 100, 5, 117.462},
+		{OneRepMaxEpley, 100, 1, 100},
+		{OneRepMaxBrzycki, 100, 40, 0},
+		{OneRepMaxEpley, 0, 5, 0},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateOneRepMax(tt.formula, tt.weight, tt.reps); math.Abs(got-tt.want) > 0.001 {
+			t.Fatalf("%s %vx%d: expected %v, got %v", tt.formula, tt.weight, tt.reps, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// ScheduleSeries is a recurring schedule. Its occurrences are materialized
+// into scheduled workouts on demand, up to MaterializedUntil.
+type ScheduleSeries struct {
+	ID                string
+	UserID            string
+	WorkoutPlanID     string
+	StartsAt          time.Time
+	Timezone          string
+	AllDay            bool
+	RRule             string
+	ExDates           []time.Time
+	MaterializedUntil *time.Time
+	CreatedAt         time.Time
+}
+
+// ScheduleChange holds optional edits to a schedule or series; nil fields are
+// left untouched. AllDay only applies together with ScheduledAt.
+type ScheduleChange struct {
+	WorkoutPlanID *string
+	ScheduledAt   *time.Time
+	AllDay        bool
+	Timezone      *string
+}
+
+type ScheduleSeriesChange struct {
+	ScheduleChange
+	RRule   *string
+	ExDates *[]time.Time
+}
+
+func (s ScheduleSeries) Location() *time.Location {
+	if loc, err := LoadTimezone(s.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+func (s ScheduleSeries) IsExcluded(date time.Time) bool {
+	date = CivilDate(date)
+	for _, d := range s.ExDates {
+		if CivilDate(d).Equal(date) {
+			return true
+		}
+	}
+	return false
+}
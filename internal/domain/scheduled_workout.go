@@ -2,14 +2,88 @@ package domain
 
 import "time"
 
+const (
+	ScheduleStatusPending   = "pending"
+	ScheduleStatusCompleted = "completed"
+	ScheduleStatusCanceled  = "canceled"
+	ScheduleStatusMissed    = "missed"
+)
+
 type ScheduledWorkout struct {
-	ID            string
-	UserID        string
+	ID               string
+	UserID           string
+	WorkoutPlanID    string
+	ScheduledDate    time.Time
+	ScheduledAt      time.Time
+	Timezone         string
+	AllDay           bool
+	Status           string
+	WorkoutSessionID string
+	CancelReason     string
+	SeriesID         string
+	OccurrenceDate   *time.Time
+	Detached         bool
+	CreatedAt        time.Time
+}
+
+// ScheduleInput describes a new schedule. When AllDay is set only the calendar
+// day of ScheduledAt is used, interpreted in Timezone. A non-empty RRule turns
+// the schedule into a recurring series starting at ScheduledAt.
+type ScheduleInput struct {
 	WorkoutPlanID string
-	ScheduledDate time.Time
-	CreatedAt     time.Time
+	ScheduledAt   time.Time
+	AllDay        bool
+	Timezone      string
+	RRule         string
+	ExDates       []time.Time
 }
 
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// ScheduledWorkoutFilter narrows schedule listings. From and To are
+// inclusive calendar days; Sort orders by date and time, newest first
+// unless it is SortAsc.
 type ScheduledWorkoutFilter struct {
-	Date *time.Time
+	Date   *time.Time
+	From   *time.Time
+	To     *time.Time
+	Status string
+	Sort   string
+}
+
+// AgendaItem is a scheduled workout together with the name of its plan.
+type AgendaItem struct {
+	ScheduledWorkout
+	PlanName string
+}
+
+var scheduleTransitions = map[string][]string{
+	ScheduleStatusPending:   {ScheduleStatusCompleted, ScheduleStatusCanceled, ScheduleStatusMissed},
+	ScheduleStatusMissed:    {ScheduleStatusCompleted, ScheduleStatusPending},
+	ScheduleStatusCompleted: {ScheduleStatusPending},
+	ScheduleStatusCanceled:  {ScheduleStatusPending},
+}
+
+func IsValidScheduleStatus(status string) bool {
+	_, ok := scheduleTransitions[status]
+	return ok
+}
+
+func CanTransitionSchedule(from, to string) bool {
+	for _, next := range scheduleTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (sw ScheduledWorkout) Location() *time.Location {
+	if loc, err := LoadTimezone(sw.Timezone); err == nil {
+		return loc
+	}
+	return time.UTC
 }
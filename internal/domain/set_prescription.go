@@ -0,0 +1,106 @@
+package domain
+
+import "math"
+
+const (
+	SetTypeWarmup  = "warmup"
+	SetTypeWorking = "working"
+	SetTypeDrop    = "drop"
+	SetTypeAMRAP   = "amrap"
+)
+
+// SetPrescription describes one set of a plan exercise. The load is either
+// an absolute weight or a percentage of the exercise's training max; with
+// neither the set is done at whatever weight the lifter picks.
+type SetPrescription struct {
+	Type string
+	// Reps is the target, or the lower bound when RepsMax is set. For an
+	// AMRAP set it is the minimum to reach.
+	Reps    int
+	RepsMax int
+	Weight  *float64
+	// Percent is a percentage of the exercise's TrainingMax.
+	Percent *float64
+	RPE     *float64
+}
+
+func (p SetPrescription) IsWarmup() bool {
+	return p.Type == SetTypeWarmup
+}
+
+// TargetWeight resolves a set's load against the exercise's training max,
+// rounded to two decimals. It returns nil when the set prescribes no load.
+func (ex WorkoutPlanExercise) TargetWeight(p SetPrescription) *float64 {
+	switch {
+	case p.Weight != nil:
+		w := *p.Weight
+		return &w
+	case p.Percent != nil && ex.TrainingMax != nil:
+		tm, pct := *ex.TrainingMax, *p.Percent
+		w := math.Round(tm*pct) / 100
+		return &w
+	default:
+		return nil
+	}
+}
+
+// ApplySetShorthand keeps the flat Sets, Reps and Weight fields in step with
+// a per-set prescription: Sets becomes the number of sets, and Reps and
+// Weight, when not given, take the first working set's values.
+func (ex *WorkoutPlanExercise) ApplySetShorthand() {
+	if len(ex.SetPrescriptions) == 0 {
+		return
+	}
+
+	ex.Sets = len(ex.SetPrescriptions)
+	first := ex.SetPrescriptions[0]
+	for _, p := range ex.SetPrescriptions {
+		if !p.IsWarmup() {
+			first = p
+			break
+		}
+	}
+	if ex.Reps <= 0 {
+		ex.Reps = first.Reps
+	}
+	if ex.Weight == 0 {
+		if w := ex.TargetWeight(first); w != nil {
+			ex.Weight = *w
+		}
+	}
+}
+
+// ValidateSetPrescriptions checks an exercise's training max and per-set
+// prescription: known set types, at least one rep, a rep range that goes
+// up, a single kind of load, an RPE between 1 and 10, and a training max
+// wherever a percentage is used.
+func ValidateSetPrescriptions(ex WorkoutPlanExercise) error {
+	if ex.TrainingMax != nil && *ex.TrainingMax <= 0 {
+		return ErrInvalidInput
+	}
+
+	for _, p := range ex.SetPrescriptions {
+		switch p.Type {
+		case SetTypeWarmup, SetTypeWorking, SetTypeDrop, SetTypeAMRAP:
+		default:
+			return ErrInvalidInput
+		}
+		if p.Reps < 1 || (p.RepsMax != 0 && p.RepsMax <= p.Reps) {
+			return ErrInvalidInput
+		}
+		if p.Weight != nil && p.Percent != nil {
+			return ErrInvalidInput
+		}
+		if p.Weight != nil && *p.Weight < 0 {
+			return ErrInvalidInput
+		}
+		if p.Percent != nil && (*p.Percent <= 0 || ex.TrainingMax == nil) {
+			return ErrInvalidInput
+		}
+		if p.RPE != nil && (*p.RPE < 1 || *p.RPE > 10) {
+			return ErrInvalidInput
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWorkoutPlanExercise_TargetWeight(t *testing.T) {
+	ex := WorkoutPlanExercise{TrainingMax: floatPtr(102.5)}
+
+	tests := []struct {
+		name string
+		set  SetPrescription
+		want *float64
+	}{
+		{name: "absolute weight", set: SetPrescription{Weight: floatPtr(60)}, want: floatPtr(60)},
+		{name: "percent of training max", set: SetPrescription{Percent: floatPtr(85)}, want: floatPtr(87.13)},
+		{name: "no load", set: SetPrescription{}, want: nil},
+	}
+
+	for _, tt := range tests {
+		got := ex.TargetWeight(tt.set)
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWorkoutPlanExercise_ApplySetShorthand(t *testing.T) {
+	ex := WorkoutPlanExercise{
+		TrainingMax: floatPtr(100),
+		SetPrescriptions: []SetPrescription{
+			{Type: SetTypeWarmup, Reps: 10, Weight: floatPtr(40)},
+			{Type: SetTypeWorking, Reps: 5, Percent: floatPtr(65)},
+			{Type: SetTypeWorking, Reps: 5, Percent: floatPtr(75)},
+			{Type: SetTypeAMRAP, Reps: 5, Percent: floatPtr(85)},
+		},
+	}
+
+	ex.ApplySetShorthand()
+	if ex.Sets != 4 || ex.Reps != 5 || ex.Weight != 65 {
+		t.Fatalf("expected 4 sets of 5 at 65, got %d sets of %d at %v", ex.Sets, ex.Reps, ex.Weight)
+	}
+
+	flat := WorkoutPlanExercise{Sets: 3, Reps: 8, Weight: 50}
+	flat.ApplySetShorthand()
+	if flat.Sets != 3 || flat.Reps != 8 || flat.Weight != 50 {
+		t.Fatalf("expected flat fields unchanged, got %+v", flat)
+	}
+}
+
+func TestValidateSetPrescriptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		ex      WorkoutPlanExercise
+		wantErr bool
+	}{
+		{name: "no prescription", ex: WorkoutPlanExercise{Sets: 3, Reps: 8}},
+		{name: "pyramid with rep range and rpe", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{
+			{Type: SetTypeWorking, Reps: 12, Weight: floatPtr(50)},
+			{Type: SetTypeWorking, Reps: 8, RepsMax: 10, Weight: floatPtr(60), RPE: floatPtr(8)},
+			{Type: SetTypeDrop, Reps: 10, Weight: floatPtr(40)},
+		}}},
+		{name: "percent with training max", ex: WorkoutPlanExercise{TrainingMax: floatPtr(100), SetPrescriptions: []SetPrescription{{Type: SetTypeAMRAP, Reps: 1, Percent: floatPtr(95)}}}},
+		{name: "percent without training max", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{{Type: SetTypeWorking, Reps: 5, Percent: floatPtr(80)}}}, wantErr: true},
+		{name: "weight and percent", ex: WorkoutPlanExercise{TrainingMax: floatPtr(100), SetPrescriptions: []SetPrescription{{Type: SetTypeWorking, Reps: 5, Weight: floatPtr(80), Percent: floatPtr(80)}}}, wantErr: true},
+		{name: "unknown type", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{{Type: "cluster", Reps: 5}}}, wantErr: true},
+		{name: "no reps", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{{Type: SetTypeWorking}}}, wantErr: true},
+		{name: "rep range going down", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{{Type: SetTypeWorking, Reps: 10, RepsMax: 8}}}, wantErr: true},
+		{name: "rpe out of range", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{{Type: SetTypeWorking, Reps: 5, RPE: floatPtr(11)}}}, wantErr: true},
+		{name: "negative weight", ex: WorkoutPlanExercise{SetPrescriptions: []SetPrescription{{Type: SetTypeWorking, Reps: 5, Weight: floatPtr(-5)}}}, wantErr: true},
+		{name: "zero training max", ex: WorkoutPlanExercise{TrainingMax: floatPtr(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSetPrescriptions(tt.ex)
+		if tt.wantErr && !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("%s: expected invalid input, got %v", tt.name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+	}
+}
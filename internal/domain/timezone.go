@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const DefaultTimezone = "UTC"
+
+// LoadTimezone resolves an IANA timezone name such as "Europe/Berlin".
+// "Local" is rejected because it reflects the server rather than the user.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return nil, ErrInvalidInput
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidInput
+	}
+	return loc, nil
+}
+
+// CivilDate returns the calendar day of t in its own location, expressed as
+// midnight UTC so it can be compared with DATE columns.
+func CivilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
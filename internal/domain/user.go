@@ -21,12 +21,27 @@ type User struct {
 	Name         string
 	Email        string
 	PasswordHash string
+	Timezone     string
+	// ReminderLeadMinutes is how long before a scheduled workout the user
+	// is reminded; 0 turns reminders off.
+	ReminderLeadMinutes int
+	// WeeklyDigest is whether the user receives the weekly training digest.
+	WeeklyDigest bool
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
 
+// UserPreferencesUpdate holds optional preference changes; nil fields are
+// left untouched.
+type UserPreferencesUpdate struct {
+	Timezone            *string
+	ReminderLeadMinutes *int
+	WeeklyDigest        *bool
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id string) (*User, error)
+	UpdatePreferences(ctx context.Context, id string, prefs UserPreferencesUpdate) error
 }
@@ -22,6 +22,26 @@ type WorkoutPlanExercise struct {
 	Reps          int
 	Weight        float64
 	OrderIndex    int
+	Group         *ExerciseGroup
+	// TrainingMax is the weight percentage-based sets are loaded from.
+	TrainingMax *float64
+	// SetPrescriptions optionally lists every set; Sets, Reps and Weight
+	// then act as a summary of it.
+	SetPrescriptions []SetPrescription
+}
+
+// WorkoutPlanExerciseDetail is a plan exercise with the exercise it refers to.
+type WorkoutPlanExerciseDetail struct {
+	WorkoutPlanExercise
+	ExerciseName string
+	Category     string
+	MuscleGroup  string
+}
+
+// WorkoutPlanDetail is a plan with its exercises in order.
+type WorkoutPlanDetail struct {
+	WorkoutPlan
+	Exercises []WorkoutPlanExerciseDetail
 }
 
 type WorkoutPlanFilter struct {
@@ -33,5 +53,11 @@ type WorkoutRepository interface {
 	UpdatePlan(ctx context.Context, plan *WorkoutPlan, exercises []WorkoutPlanExercise) error
 	GetPlansByUser(ctx context.Context, userID string, pagination Pagination, filters WorkoutPlanFilter) (PaginatedResult[WorkoutPlan], error)
 	GetPlanByID(ctx context.Context, id string, userID string) (*WorkoutPlan, error)
+	GetPlanDetail(ctx context.Context, id string, userID string) (*WorkoutPlanDetail, error)
+	GetPlanExercises(ctx context.Context, planID string) ([]WorkoutPlanExercise, error)
 	DeletePlan(ctx context.Context, id string, userID string) error
+	GetPlanVersions(ctx context.Context, planID string, pagination Pagination) (PaginatedResult[WorkoutPlanVersion], error)
+	GetPlanVersion(ctx context.Context, planID string, version int) (*WorkoutPlanVersion, error)
+	GetLatestPlanVersion(ctx context.Context, planID string) (*WorkoutPlanVersion, error)
+	RestorePlanVersion(ctx context.Context, planID string, userID string, version int) error
 }
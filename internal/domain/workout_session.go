@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+const (
+	SessionStatusInProgress = "in_progress"
+	SessionStatusCompleted  = "completed"
+)
+
+type WorkoutSession struct {
+	ID            string
+	UserID        string
+	WorkoutPlanID string
+	// PlanVersion is the plan version the session was started from, or 0.
+	PlanVersion int
+	StartedAt   time.Time
+	CompletedAt *time.Time
+	Notes       string
+	Exercises   []WorkoutSessionExercise
+}
+
+type WorkoutSessionExercise struct {
+	ID               string
+	WorkoutSessionID string
+	ExerciseID       string
+	Sets             int
+	Reps             int
+	Weight           float64
+	ActualReps       *int
+	ActualWeight     *float64
+	OrderIndex       int
+	// Group is copied from the plan exercise the session was started from.
+	Group   *ExerciseGroup
+	SetLogs []WorkoutSessionSet
+}
+
+type WorkoutSessionSet struct {
+	ID                       string
+	WorkoutSessionExerciseID string
+	SetNumber                int
+	TargetReps               *int
+	TargetWeight             *float64
+	ActualReps               *int
+	ActualWeight             *float64
+	RPE                      *float64
+	RIR                      *int
+	IsWarmup                 bool
+	CompletedAt              *time.Time
+	// SetType, TargetRepsMax and TargetRPE come from the plan's per-set
+	// prescription, when it has one.
+	SetType       string
+	TargetRepsMax *int
+	TargetRPE     *float64
+}
+
+type SessionSetInput struct {
+	TargetReps   *int
+	TargetWeight *float64
+	ActualReps   *int
+	ActualWeight *float64
+	RPE          *float64
+	RIR          *int
+	IsWarmup     *bool
+	CompletedAt  *time.Time
+}
+
+type WorkoutSessionFilter struct {
+	Status string
+}
+
+func (s WorkoutSession) IsFinished() bool {
+	return s.CompletedAt != nil
+}
+
+func (s WorkoutSession) FindExercise(sessionExerciseID string) (*WorkoutSessionExercise, bool) {
+	for i := range s.Exercises {
+		if s.Exercises[i].ID == sessionExerciseID {
+			return &s.Exercises[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s WorkoutSession) FindSet(setID string) (*WorkoutSessionSet, bool) {
+	for i := range s.Exercises {
+		for j := range s.Exercises[i].SetLogs {
+			if s.Exercises[i].SetLogs[j].ID == setID {
+				return &s.Exercises[i].SetLogs[j], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (s WorkoutSession) Status() string {
+	if s.IsFinished() {
+		return SessionStatusCompleted
+	}
+	return SessionStatusInProgress
+}
+
+func (st WorkoutSessionSet) IsCompleted() bool {
+	return st.CompletedAt != nil
+}
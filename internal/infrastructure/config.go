@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,6 +18,27 @@ type Config struct {
 	DBName     string
 	DBSSLMode  string
 	JWTSecret  string
+
+	// ReminderInterval is how often due reminders are looked up. Reminders
+	// are only sent when at least one channel below is configured.
+	ReminderInterval      time.Duration
+	ReminderWebhookURL    string
+	ReminderWebhookSecret string
+	SMTPHost              string
+	SMTPPort              string
+	SMTPUsername          string
+	SMTPPassword          string
+	SMTPFrom              string
+
+	// MissedInterval is how often overdue pending schedules are marked
+	// missed, MissedGrace how long after a schedule ends that happens.
+	MissedInterval time.Duration
+	MissedGrace    time.Duration
+
+	// DigestInterval is how often weekly digests are queued and MailInterval
+	// how often the mail outbox is flushed. Both need SMTP to be configured.
+	DigestInterval time.Duration
+	MailInterval   time.Duration
 }
 
 func LoadConfig() (Config, error) {
@@ -36,6 +58,14 @@ func LoadConfig() (Config, error) {
 		DBName:     os.Getenv("DB_NAME"),
 		DBSSLMode:  os.Getenv("DB_SSLMODE"),
 		JWTSecret:  os.Getenv("JWT_SECRET"),
+
+		ReminderWebhookURL:    os.Getenv("REMINDER_WEBHOOK_URL"),
+		ReminderWebhookSecret: os.Getenv("REMINDER_WEBHOOK_SECRET"),
+		SMTPHost:              os.Getenv("SMTP_HOST"),
+		SMTPPort:              os.Getenv("SMTP_PORT"),
+		SMTPUsername:          os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:          os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:              os.Getenv("SMTP_FROM"),
 	}
 
 	if cfg.DBPort == "" {
@@ -45,6 +75,27 @@ func LoadConfig() (Config, error) {
 		cfg.DBSSLMode = "disable"
 	}
 
+	if cfg.SMTPPort == "" {
+		cfg.SMTPPort = "587"
+	}
+
+	var err error
+	if cfg.ReminderInterval, err = durationEnv("REMINDER_INTERVAL", time.Minute, false); err != nil {
+		return Config{}, err
+	}
+	if cfg.MissedInterval, err = durationEnv("MISSED_INTERVAL", 15*time.Minute, false); err != nil {
+		return Config{}, err
+	}
+	if cfg.MissedGrace, err = durationEnv("MISSED_GRACE", 12*time.Hour, true); err != nil {
+		return Config{}, err
+	}
+	if cfg.DigestInterval, err = durationEnv("DIGEST_INTERVAL", time.Hour, false); err != nil {
+		return Config{}, err
+	}
+	if cfg.MailInterval, err = durationEnv("MAIL_INTERVAL", time.Minute, false); err != nil {
+		return Config{}, err
+	}
+
 	if cfg.DBName == "" {
 		return Config{}, errors.New("DB_NAME is required")
 	}
@@ -54,10 +105,31 @@ func LoadConfig() (Config, error) {
 	if cfg.JWTSecret == "" {
 		return Config{}, errors.New("JWT_SECRET is required")
 	}
+	if cfg.SMTPHost != "" && cfg.SMTPFrom == "" {
+		return Config{}, errors.New("SMTP_FROM is required when SMTP_HOST is set")
+	}
 
 	return cfg, nil
 }
 
+// durationEnv reads a Go duration such as "15m" from key, falling back to def
+// when it is unset.
+func durationEnv(key string, def time.Duration, allowZero bool) (time.Duration, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%s must be a valid duration", key)
+	}
+	if d == 0 && !allowZero {
+		return 0, fmt.Errorf("%s must be greater than zero", key)
+	}
+	return d, nil
+}
+
 func (c Config) DSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
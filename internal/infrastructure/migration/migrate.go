@@ -33,7 +33,17 @@ func RunMigrations(db *sql.DB) error {
 		}
 	}
 
-	if _, err := db.Exec(`
+	for _, m := range migrations {
+		if _, err := db.Exec(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var migrations = []string{
+	`
 		ALTER TABLE IF EXISTS scheduled_workouts
 			ADD COLUMN IF NOT EXISTS user_id UUID,
 			ADD COLUMN IF NOT EXISTS scheduled_date DATE;
@@ -63,9 +73,359 @@ func RunMigrations(db *sql.DB) error {
 
 		CREATE INDEX IF NOT EXISTS idx_scheduled_user_date
 		ON scheduled_workouts(user_id, scheduled_date);
-	`); err != nil {
-		return err
-	}
+	`,
+	`
+		ALTER TABLE IF EXISTS workout_session_exercises
+			ADD COLUMN IF NOT EXISTS order_index INTEGER NOT NULL DEFAULT 0;
 
-	return nil
+		CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_started
+		ON workout_sessions(user_id, started_at DESC);
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS workout_session_sets (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			workout_session_exercise_id UUID NOT NULL,
+			set_number INTEGER NOT NULL,
+			target_reps INTEGER,
+			target_weight NUMERIC(6,2),
+			actual_reps INTEGER,
+			actual_weight NUMERIC(6,2),
+			rpe NUMERIC(3,1),
+			rir INTEGER,
+			is_warmup BOOLEAN NOT NULL DEFAULT false,
+			completed_at TIMESTAMP,
+			CONSTRAINT workout_session_sets_session_exercise_id_fkey
+				FOREIGN KEY (workout_session_exercise_id) REFERENCES workout_session_exercises(id) ON DELETE CASCADE,
+			CONSTRAINT workout_session_sets_set_number_check CHECK (set_number > 0),
+			CONSTRAINT workout_session_sets_target_reps_check CHECK (target_reps IS NULL OR target_reps > 0),
+			CONSTRAINT workout_session_sets_target_weight_check CHECK (target_weight IS NULL OR target_weight >= 0),
+			CONSTRAINT workout_session_sets_actual_reps_check CHECK (actual_reps IS NULL OR actual_reps >= 0),
+			CONSTRAINT workout_session_sets_actual_weight_check CHECK (actual_weight IS NULL OR actual_weight >= 0),
+			CONSTRAINT workout_session_sets_rpe_check CHECK (rpe IS NULL OR (rpe >= 1 AND rpe <= 10)),
+			CONSTRAINT workout_session_sets_rir_check CHECK (rir IS NULL OR rir >= 0),
+			CONSTRAINT workout_session_sets_number_unique UNIQUE (workout_session_exercise_id, set_number)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_workout_session_sets_session_exercise_id
+		ON workout_session_sets(workout_session_exercise_id);
+	`,
+	`
+		ALTER TABLE IF EXISTS scheduled_workouts
+			ADD COLUMN IF NOT EXISTS workout_session_id UUID,
+			ADD COLUMN IF NOT EXISTS cancel_reason TEXT;
+
+		ALTER TABLE scheduled_workouts
+			DROP CONSTRAINT IF EXISTS scheduled_workouts_status_check;
+
+		ALTER TABLE scheduled_workouts
+			ADD CONSTRAINT scheduled_workouts_status_check
+			CHECK (status IN ('pending', 'completed', 'canceled', 'missed'));
+
+		ALTER TABLE scheduled_workouts
+			DROP CONSTRAINT IF EXISTS scheduled_workouts_workout_session_id_fkey;
+
+		ALTER TABLE scheduled_workouts
+			ADD CONSTRAINT scheduled_workouts_workout_session_id_fkey
+			FOREIGN KEY (workout_session_id) REFERENCES workout_sessions(id) ON DELETE SET NULL;
+
+		CREATE INDEX IF NOT EXISTS idx_scheduled_user_status
+		ON scheduled_workouts(user_id, status);
+	`,
+	`
+		ALTER TABLE IF EXISTS users
+			ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+
+		ALTER TABLE IF EXISTS scheduled_workouts
+			ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC',
+			ADD COLUMN IF NOT EXISTS all_day BOOLEAN NOT NULL DEFAULT true;
+
+		ALTER TABLE scheduled_workouts
+			ALTER COLUMN all_day SET DEFAULT false;
+
+		DO $$
+		BEGIN
+			IF EXISTS (
+				SELECT 1
+				FROM information_schema.columns
+				WHERE table_schema = 'public'
+					AND table_name = 'scheduled_workouts'
+					AND column_name = 'scheduled_at'
+					AND data_type = 'timestamp without time zone'
+			) THEN
+				ALTER TABLE scheduled_workouts
+					ALTER COLUMN scheduled_at TYPE TIMESTAMPTZ USING scheduled_at AT TIME ZONE 'UTC';
+			END IF;
+		END $$;
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS schedule_series (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			workout_plan_id UUID NOT NULL,
+			starts_at TIMESTAMPTZ NOT NULL,
+			timezone TEXT NOT NULL DEFAULT 'UTC',
+			all_day BOOLEAN NOT NULL DEFAULT false,
+			rrule TEXT NOT NULL,
+			exdates DATE[] NOT NULL DEFAULT '{}',
+			materialized_until DATE,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			updated_at TIMESTAMP NOT NULL DEFAULT now(),
+			CONSTRAINT schedule_series_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			CONSTRAINT schedule_series_workout_plan_id_fkey
+				FOREIGN KEY (workout_plan_id) REFERENCES workout_plans(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_schedule_series_user_id
+		ON schedule_series(user_id);
+
+		ALTER TABLE IF EXISTS scheduled_workouts
+			ADD COLUMN IF NOT EXISTS series_id UUID,
+			ADD COLUMN IF NOT EXISTS occurrence_date DATE,
+			ADD COLUMN IF NOT EXISTS detached BOOLEAN NOT NULL DEFAULT false;
+
+		ALTER TABLE scheduled_workouts
+			DROP CONSTRAINT IF EXISTS scheduled_workouts_series_id_fkey;
+
+		ALTER TABLE scheduled_workouts
+			ADD CONSTRAINT scheduled_workouts_series_id_fkey
+			FOREIGN KEY (series_id) REFERENCES schedule_series(id) ON DELETE SET NULL;
+
+		CREATE UNIQUE INDEX IF NOT EXISTS scheduled_workouts_series_occurrence
+		ON scheduled_workouts(series_id, occurrence_date);
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS calendar_feeds (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL UNIQUE,
+			token_hash TEXT NOT NULL UNIQUE,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			CONSTRAINT calendar_feeds_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+	`,
+	`
+		ALTER TABLE users
+		ADD COLUMN IF NOT EXISTS reminder_lead_minutes INTEGER NOT NULL DEFAULT 60;
+
+		ALTER TABLE users
+		DROP CONSTRAINT IF EXISTS users_reminder_lead_minutes_check;
+
+		ALTER TABLE users
+		ADD CONSTRAINT users_reminder_lead_minutes_check
+			CHECK (reminder_lead_minutes BETWEEN 0 AND 10080);
+
+		CREATE TABLE IF NOT EXISTS reminder_deliveries (
+			scheduled_workout_id UUID NOT NULL,
+			channel TEXT NOT NULL,
+			scheduled_at TIMESTAMPTZ NOT NULL,
+			sent_at TIMESTAMP NOT NULL DEFAULT now(),
+			PRIMARY KEY (scheduled_workout_id, channel, scheduled_at),
+			CONSTRAINT reminder_deliveries_scheduled_workout_id_fkey
+				FOREIGN KEY (scheduled_workout_id) REFERENCES scheduled_workouts(id) ON DELETE CASCADE
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_scheduled_pending_at
+		ON scheduled_workouts(scheduled_at)
+		WHERE status = 'pending';
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS personal_records (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			exercise_id UUID NOT NULL,
+			workout_session_id UUID NOT NULL,
+			record_type TEXT NOT NULL,
+			value NUMERIC(12,2) NOT NULL,
+			previous_value NUMERIC(12,2),
+			weight NUMERIC(6,2) NOT NULL DEFAULT 0,
+			reps INTEGER NOT NULL DEFAULT 0,
+			achieved_at TIMESTAMP NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			CONSTRAINT personal_records_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			CONSTRAINT personal_records_exercise_id_fkey
+				FOREIGN KEY (exercise_id) REFERENCES exercises(id),
+			CONSTRAINT personal_records_workout_session_id_fkey
+				FOREIGN KEY (workout_session_id) REFERENCES workout_sessions(id) ON DELETE CASCADE,
+			CONSTRAINT personal_records_record_type_check
+				CHECK (record_type IN ('max_weight', 'max_reps', 'estimated_1rm', 'session_volume'))
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_personal_records_user_exercise
+		ON personal_records(user_id, exercise_id, record_type);
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS report_daily_rollups (
+			user_id UUID NOT NULL,
+			day DATE NOT NULL,
+			sessions INTEGER NOT NULL DEFAULT 0,
+			duration_seconds BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (user_id, day),
+			CONSTRAINT report_daily_rollups_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+
+		CREATE TABLE IF NOT EXISTS report_daily_exercise_rollups (
+			user_id UUID NOT NULL,
+			exercise_id UUID NOT NULL,
+			day DATE NOT NULL,
+			sessions INTEGER NOT NULL DEFAULT 0,
+			sets INTEGER NOT NULL DEFAULT 0,
+			volume NUMERIC NOT NULL DEFAULT 0,
+			PRIMARY KEY (user_id, day, exercise_id),
+			CONSTRAINT report_daily_exercise_rollups_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			CONSTRAINT report_daily_exercise_rollups_exercise_id_fkey
+				FOREIGN KEY (exercise_id) REFERENCES exercises(id)
+		);
+
+		CREATE TABLE IF NOT EXISTS report_rollup_state (
+			user_id UUID PRIMARY KEY,
+			timezone TEXT NOT NULL,
+			built_at TIMESTAMP NOT NULL DEFAULT now(),
+			CONSTRAINT report_rollup_state_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		);
+	`,
+	`
+		ALTER TABLE users
+		ADD COLUMN IF NOT EXISTS weekly_digest BOOLEAN NOT NULL DEFAULT true;
+
+		CREATE TABLE IF NOT EXISTS mail_outbox (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			user_id UUID NOT NULL,
+			kind TEXT NOT NULL,
+			period_start DATE NOT NULL,
+			to_name TEXT NOT NULL DEFAULT '',
+			to_address TEXT NOT NULL,
+			subject TEXT NOT NULL,
+			text_body TEXT NOT NULL,
+			html_body TEXT NOT NULL DEFAULT '',
+			status TEXT NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+			sent_at TIMESTAMP,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			CONSTRAINT mail_outbox_user_id_fkey
+				FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			CONSTRAINT mail_outbox_status_check
+				CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+			CONSTRAINT mail_outbox_kind_period_key
+				UNIQUE (kind, user_id, period_start)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_mail_outbox_due
+		ON mail_outbox(next_attempt_at)
+		WHERE status IN ('pending', 'sending');
+	`,
+	`
+		CREATE TABLE IF NOT EXISTS workout_plan_versions (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			workout_plan_id UUID NOT NULL,
+			version INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			notes TEXT NOT NULL DEFAULT '',
+			restored_from INTEGER,
+			created_at TIMESTAMP NOT NULL DEFAULT now(),
+			CONSTRAINT workout_plan_versions_workout_plan_id_fkey
+				FOREIGN KEY (workout_plan_id) REFERENCES workout_plans(id) ON DELETE CASCADE,
+			CONSTRAINT workout_plan_versions_plan_version_key
+				UNIQUE (workout_plan_id, version)
+		);
+
+		CREATE TABLE IF NOT EXISTS workout_plan_version_exercises (
+			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+			plan_version_id UUID NOT NULL,
+			exercise_id UUID NOT NULL,
+			sets INTEGER NOT NULL,
+			reps INTEGER NOT NULL,
+			weight NUMERIC(6,2),
+			order_index INTEGER NOT NULL,
+			CONSTRAINT workout_plan_version_exercises_plan_version_id_fkey
+				FOREIGN KEY (plan_version_id) REFERENCES workout_plan_versions(id) ON DELETE CASCADE,
+			CONSTRAINT workout_plan_version_exercises_exercise_id_fkey
+				FOREIGN KEY (exercise_id) REFERENCES exercises(id)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_workout_plan_version_exercises_version
+		ON workout_plan_version_exercises(plan_version_id, order_index);
+
+		INSERT INTO workout_plan_versions (workout_plan_id, version, name, notes, created_at)
+		SELECT wp.id, 1, wp.name, COALESCE(wp.notes, ''), wp.updated_at
+		FROM workout_plans wp
+		WHERE NOT EXISTS (SELECT 1 FROM workout_plan_versions v WHERE v.workout_plan_id = wp.id);
+
+		INSERT INTO workout_plan_version_exercises (plan_version_id, exercise_id, sets, reps, weight, order_index)
+		SELECT v.id, wpe.exercise_id, wpe.sets, wpe.reps, wpe.weight, wpe.order_index
+		FROM workout_plan_versions v
+		JOIN workout_plan_exercises wpe ON wpe.workout_plan_id = v.workout_plan_id
+		WHERE v.version = 1
+		AND NOT EXISTS (SELECT 1 FROM workout_plan_version_exercises ve WHERE ve.plan_version_id = v.id);
+
+		ALTER TABLE workout_sessions
+		ADD COLUMN IF NOT EXISTS plan_version INTEGER;
+	`,
+	`
+		ALTER TABLE workout_plan_exercises
+		ADD COLUMN IF NOT EXISTS group_id TEXT,
+		ADD COLUMN IF NOT EXISTS group_type TEXT,
+		ADD COLUMN IF NOT EXISTS group_rounds INTEGER,
+		ADD COLUMN IF NOT EXISTS group_rest_seconds INTEGER;
+
+		ALTER TABLE workout_plan_version_exercises
+		ADD COLUMN IF NOT EXISTS group_id TEXT,
+		ADD COLUMN IF NOT EXISTS group_type TEXT,
+		ADD COLUMN IF NOT EXISTS group_rounds INTEGER,
+		ADD COLUMN IF NOT EXISTS group_rest_seconds INTEGER;
+
+		ALTER TABLE workout_session_exercises
+		ADD COLUMN IF NOT EXISTS group_id TEXT,
+		ADD COLUMN IF NOT EXISTS group_type TEXT,
+		ADD COLUMN IF NOT EXISTS group_rounds INTEGER,
+		ADD COLUMN IF NOT EXISTS group_rest_seconds INTEGER;
+
+		ALTER TABLE workout_plan_exercises
+		DROP CONSTRAINT IF EXISTS workout_plan_exercises_group_type_check;
+
+		ALTER TABLE workout_plan_exercises
+		ADD CONSTRAINT workout_plan_exercises_group_type_check
+			CHECK (group_type IS NULL OR group_type IN ('superset', 'circuit', 'giant_set'));
+
+		ALTER TABLE workout_plan_version_exercises
+		DROP CONSTRAINT IF EXISTS workout_plan_version_exercises_group_type_check;
+
+		ALTER TABLE workout_plan_version_exercises
+		ADD CONSTRAINT workout_plan_version_exercises_group_type_check
+			CHECK (group_type IS NULL OR group_type IN ('superset', 'circuit', 'giant_set'));
+
+		ALTER TABLE workout_session_exercises
+		DROP CONSTRAINT IF EXISTS workout_session_exercises_group_type_check;
+
+		ALTER TABLE workout_session_exercises
+		ADD CONSTRAINT workout_session_exercises_group_type_check
+			CHECK (group_type IS NULL OR group_type IN ('superset', 'circuit', 'giant_set'));
+	`,
+	`
+		ALTER TABLE workout_plan_exercises
+		ADD COLUMN IF NOT EXISTS training_max NUMERIC(6,2),
+		ADD COLUMN IF NOT EXISTS set_prescriptions JSONB;
+
+		ALTER TABLE workout_plan_version_exercises
+		ADD COLUMN IF NOT EXISTS training_max NUMERIC(6,2),
+		ADD COLUMN IF NOT EXISTS set_prescriptions JSONB;
+
+		ALTER TABLE workout_session_sets
+		ADD COLUMN IF NOT EXISTS set_type TEXT,
+		ADD COLUMN IF NOT EXISTS target_reps_max INTEGER,
+		ADD COLUMN IF NOT EXISTS target_rpe NUMERIC(3,1);
+	`,
+	`
+		ALTER TABLE reminder_deliveries
+		ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 1,
+		ADD COLUMN IF NOT EXISTS last_error TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ,
+		ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ;
+	`,
 }
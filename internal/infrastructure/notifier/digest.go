@@ -0,0 +1,118 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"math"
+	"strconv"
+	texttemplate "text/template"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// DigestRenderer renders weekly digests from the embedded templates, as
+// plain text and HTML.
+type DigestRenderer struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+func NewDigestRenderer() (*DigestRenderer, error) {
+	text, err := texttemplate.New("weekly_digest.txt.tmpl").Funcs(digestFuncs).ParseFS(templateFS, "templates/weekly_digest.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("digest renderer: %w", err)
+	}
+	html, err := htmltemplate.New("weekly_digest.html.tmpl").Funcs(digestFuncs).ParseFS(templateFS, "templates/weekly_digest.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("digest renderer: %w", err)
+	}
+	return &DigestRenderer{text: text, html: html}, nil
+}
+
+func (r *DigestRenderer) RenderDigest(d domain.WeeklyDigest) (domain.MailContent, error) {
+	var text, html bytes.Buffer
+	if err := r.text.Execute(&text, d); err != nil {
+		return domain.MailContent{}, fmt.Errorf("render digest: %w", err)
+	}
+	if err := r.html.Execute(&html, d); err != nil {
+		return domain.MailContent{}, fmt.Errorf("render digest: %w", err)
+	}
+
+	return domain.MailContent{
+		Subject: fmt.Sprintf("Your training week: %s to %s", formatDigestDate(d.WeekStart), formatDigestDate(d.WeekEnd())),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+var digestFuncs = map[string]interface{}{
+	"date":     formatDigestDate,
+	"duration": formatDigestDuration,
+	"number":   formatDigestNumber,
+	"change":   volumeChange,
+	"record":   recordSummary,
+	"when":     scheduleTime,
+}
+
+func formatDigestDate(t time.Time) string {
+	return t.Format("Mon 2 Jan")
+}
+
+func formatDigestDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func formatDigestNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func volumeChange(d domain.WeeklyDigest) string {
+	change := d.VolumeChange()
+	switch {
+	case change == nil:
+		return "no volume the week before"
+	case *change > 0:
+		return fmt.Sprintf("up %s%% on the week before", formatDigestNumber(*change))
+	case *change < 0:
+		return fmt.Sprintf("down %s%% on the week before", formatDigestNumber(-*change))
+	default:
+		return "same as the week before"
+	}
+}
+
+func recordSummary(pr domain.PersonalRecord) string {
+	switch pr.Type {
+	case domain.RecordMaxWeight:
+		return fmt.Sprintf("heaviest weight %s", formatDigestNumber(pr.Value))
+	case domain.RecordMaxReps:
+		return fmt.Sprintf("%s reps at %s", formatDigestNumber(pr.Value), formatDigestNumber(pr.Weight))
+	case domain.RecordOneRepMax:
+		return fmt.Sprintf("estimated 1RM %s", formatDigestNumber(pr.Value))
+	case domain.RecordSessionVolume:
+		return fmt.Sprintf("session volume %s", formatDigestNumber(pr.Value))
+	default:
+		return formatDigestNumber(pr.Value)
+	}
+}
+
+// scheduleTime formats a schedule in its own timezone.
+func scheduleTime(item domain.AgendaItem) string {
+	if item.AllDay {
+		return formatDigestDate(item.ScheduledDate) + ", all day"
+	}
+	loc, err := domain.LoadTimezone(item.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return item.ScheduledAt.In(loc).Format("Mon 2 Jan, 15:04")
+}
@@ -0,0 +1,23 @@
+// Package notifier holds the reminder delivery channels and the email
+// transport and templates for outbox mail.
+package notifier
+
+import (
+	"fmt"
+
+	"workout-tracker/internal/domain"
+)
+
+func reminderSubject(r domain.Reminder) string {
+	return "Workout reminder: " + r.PlanName
+}
+
+// reminderBody describes when the workout starts, in the schedule's own
+// timezone.
+func reminderBody(r domain.Reminder) string {
+	at := r.ScheduledAt.In(r.Location())
+	if r.AllDay {
+		return fmt.Sprintf("Hi %s,\n\n%s is scheduled for %s.\n", r.UserName, r.PlanName, at.Format("Monday, 2 January 2006"))
+	}
+	return fmt.Sprintf("Hi %s,\n\n%s is scheduled for %s (%s).\n", r.UserName, r.PlanName, at.Format("Monday, 2 January 2006 at 15:04"), r.Timezone)
+}
@@ -0,0 +1,325 @@
+package notifier
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+func testReminder() domain.Reminder {
+	return domain.Reminder{
+		ScheduleID:    "s1",
+		UserID:        "u1",
+		UserName:      "Budi",
+		Email:         "budi@example.com",
+		WorkoutPlanID: "p1",
+		PlanName:      "Push Day",
+		ScheduledAt:   time.Date(2026, 3, 10, 0, 30, 0, 0, time.UTC),
+		Timezone:      "Asia/Jakarta",
+	}
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var gotBody []byte
+	var gotSig string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSig = r.Header.Get(SignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookNotifier(srv.URL, "shh").Notify(context.Background(), testReminder()); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload["schedule_id"] != "s1" || payload["plan_name"] != "Push Day" {
+		t.Fatalf("unexpected payload: %s", gotBody)
+	}
+	if payload["scheduled_at"] != "2026-03-10T07:30:00+07:00" {
+		t.Fatalf("expected local scheduled_at, got %v", payload["scheduled_at"])
+	}
+
+	mac := hmac.New(sha256.New, []byte("shh"))
+	mac.Write(gotBody)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); gotSig != want {
+		t.Fatalf("expected signature %q, got %q", want, gotSig)
+	}
+}
+
+func TestWebhookNotifier_NotifyRejectsErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookNotifier(srv.URL, "").Notify(context.Background(), testReminder()); err == nil {
+		t.Fatal("expected error for 502 response")
+	}
+}
+
+// fakeSMTP accepts a single message and returns what it received.
+func fakeSMTP(t *testing.T) (addr string, received <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		rd := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+		reply("220 localhost ESMTP")
+
+		var transcript strings.Builder
+		for {
+			line, err := rd.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM"), strings.HasPrefix(cmd, "RCPT TO"):
+				transcript.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 end with .")
+				for {
+					l, err := rd.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					transcript.WriteString(l)
+				}
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				out <- transcript.String()
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), out
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+
+	n, err := NewSMTPNotifier(host, port, "", "", "Workout Tracker <noreply@example.com>")
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+	if err := n.Notify(context.Background(), testReminder()); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	var transcript string
+	select {
+	case transcript = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp server received nothing")
+	}
+
+	if !strings.Contains(transcript, "RCPT TO:<budi@example.com>") {
+		t.Fatalf("wrong recipient:\n%s", transcript)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(transcript[strings.Index(transcript, "From:"):]))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	if got := msg.Header.Get("Subject"); got != "Workout reminder: Push Day" {
+		t.Fatalf("unexpected subject %q", got)
+	}
+	body, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if !strings.Contains(string(body), "Tuesday, 10 March 2026 at 07:30 (Asia/Jakarta)") {
+		t.Fatalf("body does not mention local time:\n%s", body)
+	}
+}
+
+func TestSMTPNotifier_NotifyStopsOnSilentServer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	n, err := NewSMTPNotifier(host, port, "", "", "noreply@example.com")
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- n.Notify(ctx, testReminder()) }()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected error from silent server")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("notify did not give up when ctx expired")
+	}
+}
+
+func TestNewSMTPNotifier_InvalidFrom(t *testing.T) {
+	if _, err := NewSMTPNotifier("localhost", "25", "", "", "not an address"); err == nil {
+		t.Fatal("expected error for invalid from address")
+	}
+}
+
+func testDigest() domain.WeeklyDigest {
+	return domain.WeeklyDigest{
+		DigestRecipient: domain.DigestRecipient{
+			UserID:    "u1",
+			Name:      "Budi",
+			Email:     "budi@example.com",
+			Timezone:  "Asia/Jakarta",
+			WeekStart: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		TrainingTotals: domain.TrainingTotals{Sessions: 3, Duration: 150 * time.Minute, Volume: 5500},
+		PreviousVolume: 5000,
+		Records: []domain.PersonalRecord{
+			{ExerciseName: "Bench & Press", Type: domain.RecordMaxWeight, Value: 102.5},
+		},
+		Upcoming: []domain.AgendaItem{{
+			ScheduledWorkout: domain.ScheduledWorkout{ScheduledAt: time.Date(2026, 3, 10, 0, 30, 0, 0, time.UTC), Timezone: "Asia/Jakarta"},
+			PlanName:         "Push Day",
+		}},
+	}
+}
+
+func TestDigestRenderer_RenderDigest(t *testing.T) {
+	r, err := NewDigestRenderer()
+	if err != nil {
+		t.Fatalf("new renderer: %v", err)
+	}
+
+	content, err := r.RenderDigest(testDigest())
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	if content.Subject != "Your training week: Mon 2 Mar to Sun 8 Mar" {
+		t.Fatalf("unexpected subject %q", content.Subject)
+	}
+	for _, want := range []string{"Sessions: 3", "Time trained: 2h 30m", "Volume: 5500 (up 10% on the week before)", "- Bench & Press: heaviest weight 102.5", "- Tue 10 Mar, 07:30: Push Day"} {
+		if !strings.Contains(content.Text, want) {
+			t.Fatalf("text does not contain %q:\n%s", want, content.Text)
+		}
+	}
+	if !strings.Contains(content.HTML, "<li>Bench &amp; Press: heaviest weight 102.5</li>") {
+		t.Fatalf("html is not escaped:\n%s", content.HTML)
+	}
+
+	d := testDigest()
+	d.Upcoming = nil
+	content, err = r.RenderDigest(d)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.Contains(content.Text, "Nothing scheduled yet.") {
+		t.Fatalf("empty schedule not mentioned:\n%s", content.Text)
+	}
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	host, port, _ := net.SplitHostPort(addr)
+
+	n, err := NewSMTPNotifier(host, port, "", "", "Workout Tracker <noreply@example.com>")
+	if err != nil {
+		t.Fatalf("new notifier: %v", err)
+	}
+	msg := domain.MailMessage{
+		ToName:      "Budi",
+		ToAddress:   "budi@example.com",
+		MailContent: domain.MailContent{Subject: "Your week", Text: "plain body", HTML: "<p>html body</p>"},
+	}
+	if err := n.Send(context.Background(), msg); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	var transcript string
+	select {
+	case transcript = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp server received nothing")
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(transcript[strings.Index(transcript, "From:"):]))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q", parsed.Header.Get("Content-Type"))
+	}
+
+	var types []string
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := mr.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		body, _ := io.ReadAll(quotedprintable.NewReader(part))
+		types = append(types, part.Header.Get("Content-Type")+": "+string(body))
+	}
+	want := []string{"text/plain; charset=utf-8: plain body", "text/html; charset=utf-8: <p>html body</p>"}
+	if strings.Join(types, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected parts %q", types)
+	}
+}
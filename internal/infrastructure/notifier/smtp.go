@@ -0,0 +1,213 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+// SMTPNotifier emails reminders to the schedule's owner. It is also the
+// transport for outbox mail.
+type SMTPNotifier struct {
+	host string
+	addr string
+	from mail.Address
+	auth smtp.Auth
+}
+
+// smtpTimeout bounds a whole delivery, from dialing to QUIT.
+const smtpTimeout = 30 * time.Second
+
+// NewSMTPNotifier sends through host:port, authenticating with PLAIN when a
+// username is given. net/smtp upgrades to TLS when the server offers
+// STARTTLS and only allows PLAIN over TLS or to localhost.
+func NewSMTPNotifier(host, port, username, password, from string) (*SMTPNotifier, error) {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("smtp notifier: invalid from address: %w", err)
+	}
+
+	n := &SMTPNotifier{host: host, addr: net.JoinHostPort(host, port), from: *addr}
+	if username != "" {
+		n.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return n, nil
+}
+
+func (n *SMTPNotifier) Channel() string {
+	return "email"
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, r domain.Reminder) error {
+	to := mail.Address{Name: r.UserName, Address: r.Email}
+	msg, err := buildMessage(n.from, to, reminderSubject(r), reminderBody(r), time.Now())
+	if err != nil {
+		return fmt.Errorf("smtp notify: %w", err)
+	}
+
+	if err := n.send(ctx, to.Address, msg); err != nil {
+		return fmt.Errorf("smtp notify: %w", err)
+	}
+
+	return nil
+}
+
+// Send delivers an outbox message, as multipart/alternative when it has an
+// HTML body.
+func (n *SMTPNotifier) Send(ctx context.Context, m domain.MailMessage) error {
+	to := mail.Address{Name: m.ToName, Address: m.ToAddress}
+	var msg []byte
+	var err error
+	if m.HTML == "" {
+		msg, err = buildMessage(n.from, to, m.Subject, m.Text, time.Now())
+	} else {
+		msg, err = buildAlternativeMessage(n.from, to, m.Subject, m.Text, m.HTML, time.Now())
+	}
+	if err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+
+	if err := n.send(ctx, to.Address, msg); err != nil {
+		return fmt.Errorf("smtp send: %w", err)
+	}
+
+	return nil
+}
+
+// send does what smtp.SendMail does, but gives up after smtpTimeout or when
+// ctx is done.
+func (n *SMTPNotifier) send(ctx context.Context, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	c, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if n.auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("server does not support AUTH")
+		}
+		if err := c.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(n.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func buildMessage(from, to mail.Address, subject, body string, date time.Time) ([]byte, error) {
+	var b bytes.Buffer
+	writeHeaders(&b, from, to, subject, date,
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+	)
+
+	if err := writeQuotedPrintable(&b, body); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func buildAlternativeMessage(from, to mail.Address, subject, text, html string, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	parts := []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	}
+	for _, p := range parts {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, p.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	writeHeaders(&b, from, to, subject, date, "Content-Type: multipart/alternative; boundary="+mw.Boundary())
+	b.Write(body.Bytes())
+	return b.Bytes(), nil
+}
+
+func writeHeaders(b *bytes.Buffer, from, to mail.Address, subject string, date time.Time, content ...string) {
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + date.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+	}
+	headers = append(headers, content...)
+	b.WriteString(strings.Join(headers, "\r\n"))
+	b.WriteString("\r\n\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(s, "\n", "\r\n"))); err != nil {
+		return err
+	}
+	return qp.Close()
+}
@@ -0,0 +1,89 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+const SignatureHeader = "X-Webhook-Signature"
+
+// WebhookNotifier posts reminders as JSON to a fixed URL. When a secret is
+// set, the body is signed with HMAC-SHA256 in SignatureHeader.
+type WebhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url, secret string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, secret: secret, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type webhookPayload struct {
+	Type          string    `json:"type"`
+	ScheduleID    string    `json:"schedule_id"`
+	UserID        string    `json:"user_id"`
+	Email         string    `json:"email"`
+	WorkoutPlanID string    `json:"workout_plan_id"`
+	PlanName      string    `json:"plan_name"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	Timezone      string    `json:"timezone"`
+	AllDay        bool      `json:"all_day"`
+	Message       string    `json:"message"`
+}
+
+func (n *WebhookNotifier) Channel() string {
+	return "webhook"
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, r domain.Reminder) error {
+	body, err := json.Marshal(webhookPayload{
+		Type:          "workout.reminder",
+		ScheduleID:    r.ScheduleID,
+		UserID:        r.UserID,
+		Email:         r.Email,
+		WorkoutPlanID: r.WorkoutPlanID,
+		PlanName:      r.PlanName,
+		ScheduledAt:   r.ScheduledAt.In(r.Location()),
+		Timezone:      r.Timezone,
+		AllDay:        r.AllDay,
+		Message:       reminderBody(r),
+	})
+	if err != nil {
+		return fmt.Errorf("webhook notify: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook notify: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook notify: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook notify: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresCalendarRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCalendarRepository(db *sql.DB) irepo.CalendarRepository {
+	return &PostgresCalendarRepository{db: db}
+}
+
+func (r *PostgresCalendarRepository) SaveFeedToken(ctx context.Context, userID string, tokenHash string) error {
+	const q = `
+		INSERT INTO calendar_feeds (user_id, token_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = now()
+	`
+
+	if _, err := r.db.ExecContext(ctx, q, userID, tokenHash); err != nil {
+		return fmt.Errorf("save feed token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCalendarRepository) GetFeedUserID(ctx context.Context, tokenHash string) (string, error) {
+	const q = `
+		SELECT user_id
+		FROM calendar_feeds
+		WHERE token_hash = $1
+	`
+
+	var userID string
+	if err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(&userID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", err
+		}
+		return "", fmt.Errorf("get feed user: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (r *PostgresCalendarRepository) DeleteFeedToken(ctx context.Context, userID string) error {
+	const q = `
+		DELETE FROM calendar_feeds
+		WHERE user_id = $1
+	`
+
+	res, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return fmt.Errorf("delete feed token: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresCalendarRepository) GetEntries(ctx context.Context, userID string, from time.Time) ([]domain.CalendarEntry, error) {
+	const q = `
+		SELECT sw.id, sw.workout_plan_id, sw.scheduled_date, sw.scheduled_at, sw.timezone, sw.all_day, sw.status, sw.created_at, wp.name
+		FROM scheduled_workouts sw
+		JOIN workout_plans wp ON wp.id = sw.workout_plan_id
+		WHERE sw.user_id = $1 AND sw.scheduled_date >= $2
+		ORDER BY sw.scheduled_at, sw.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, from)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar entries: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.CalendarEntry, 0)
+	planIDs := make([]string, 0)
+	seen := map[string]bool{}
+	for rows.Next() {
+		var e domain.CalendarEntry
+		sw := &e.Schedule
+		if err := rows.Scan(&sw.ID, &sw.WorkoutPlanID, &sw.ScheduledDate, &sw.ScheduledAt, &sw.Timezone, &sw.AllDay, &sw.Status, &sw.CreatedAt, &e.PlanName); err != nil {
+			return nil, fmt.Errorf("get calendar entries: %w", err)
+		}
+		sw.UserID = userID
+		out = append(out, e)
+		if !seen[sw.WorkoutPlanID] {
+			seen[sw.WorkoutPlanID] = true
+			planIDs = append(planIDs, sw.WorkoutPlanID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get calendar entries: %w", err)
+	}
+	if len(planIDs) == 0 {
+		return out, nil
+	}
+
+	const exercisesQ = `
+		SELECT wpe.workout_plan_id, e.name, wpe.sets, wpe.reps, COALESCE(wpe.weight, 0)
+		FROM workout_plan_exercises wpe
+		JOIN exercises e ON e.id = wpe.exercise_id
+		WHERE wpe.workout_plan_id = ANY($1::uuid[])
+		ORDER BY wpe.workout_plan_id, wpe.order_index
+	`
+
+	exRows, err := r.db.QueryContext(ctx, exercisesQ, pq.Array(planIDs))
+	if err != nil {
+		return nil, fmt.Errorf("get calendar entries: %w", err)
+	}
+	defer exRows.Close()
+
+	byPlan := map[string][]domain.CalendarExercise{}
+	for exRows.Next() {
+		var planID string
+		var ex domain.CalendarExercise
+		if err := exRows.Scan(&planID, &ex.Name, &ex.Sets, &ex.Reps, &ex.Weight); err != nil {
+			return nil, fmt.Errorf("get calendar entries: %w", err)
+		}
+		byPlan[planID] = append(byPlan[planID], ex)
+	}
+	if err := exRows.Err(); err != nil {
+		return nil, fmt.Errorf("get calendar entries: %w", err)
+	}
+
+	for i := range out {
+		out[i].Exercises = byPlan[out[i].Schedule.WorkoutPlanID]
+	}
+
+	return out, nil
+}
+
+func (r *PostgresCalendarRepository) GetSchedulesBetween(ctx context.Context, userID string, from, to time.Time) ([]domain.AgendaItem, error) {
+	const q = `
+		SELECT sw.id, sw.user_id, sw.workout_plan_id, sw.scheduled_date, sw.scheduled_at, sw.timezone, sw.all_day, sw.status, sw.workout_session_id, sw.cancel_reason, sw.series_id, sw.occurrence_date, sw.detached, sw.created_at, wp.name
+		FROM scheduled_workouts sw
+		JOIN workout_plans wp ON wp.id = sw.workout_plan_id
+		WHERE sw.user_id = $1 AND sw.scheduled_date BETWEEN $2 AND $3
+		ORDER BY sw.scheduled_at, sw.created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get schedules between: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.AgendaItem, 0)
+	for rows.Next() {
+		var planName string
+		sw, err := scanScheduledWorkout(rows, &planName)
+		if err != nil {
+			return nil, fmt.Errorf("get schedules between: %w", err)
+		}
+		out = append(out, domain.AgendaItem{ScheduledWorkout: *sw, PlanName: planName})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get schedules between: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresCalendarRepository) GetCompletedSessions(ctx context.Context, userID string, start, end time.Time) ([]domain.WorkoutSession, error) {
+	const q = `
+		SELECT id, user_id, workout_plan_id, started_at, completed_at, notes
+		FROM workout_sessions
+		WHERE user_id = $1 AND completed_at >= $2 AND completed_at < $3
+		ORDER BY completed_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, start.UTC(), end.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("get completed sessions: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.WorkoutSession, 0)
+	for rows.Next() {
+		var s domain.WorkoutSession
+		var planID sql.NullString
+		var completedAt sql.NullTime
+		var notes sql.NullString
+		if err := rows.Scan(&s.ID, &s.UserID, &planID, &s.StartedAt, &completedAt, &notes); err != nil {
+			return nil, fmt.Errorf("get completed sessions: %w", err)
+		}
+		s.WorkoutPlanID = planID.String
+		s.Notes = notes.String
+		if completedAt.Valid {
+			s.CompletedAt = &completedAt.Time
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get completed sessions: %w", err)
+	}
+
+	return out, nil
+}
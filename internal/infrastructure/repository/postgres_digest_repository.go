@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresDigestRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresDigestRepository(db *sql.DB) irepo.DigestRepository {
+	return &PostgresDigestRepository{db: db}
+}
+
+func (r *PostgresDigestRepository) GetDueRecipients(ctx context.Context, now time.Time, after string, limit int) ([]domain.DigestRecipient, error) {
+	// date_trunc('week') starts weeks on Monday, like domain.CalendarWeek.
+	const q = `
+		SELECT u.id, u.name, u.email, u.timezone, w.week_start
+		FROM users u
+		CROSS JOIN LATERAL (
+			SELECT (date_trunc('week', $1::timestamp AT TIME ZONE 'UTC' AT TIME ZONE u.timezone) - interval '7 days')::date AS week_start
+		) w
+		WHERE u.weekly_digest
+		AND ($4::uuid IS NULL OR u.id > $4::uuid)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM mail_outbox m
+			WHERE m.user_id = u.id AND m.kind = $2 AND m.period_start = w.week_start
+		)
+		ORDER BY u.id
+		LIMIT $3
+	`
+
+	var afterID interface{} = nil
+	if after != "" {
+		afterID = after
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, now.UTC(), domain.MailKindWeeklyDigest, limit, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("get digest recipients: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.DigestRecipient, 0)
+	for rows.Next() {
+		var rec domain.DigestRecipient
+		if err := rows.Scan(&rec.UserID, &rec.Name, &rec.Email, &rec.Timezone, &rec.WeekStart); err != nil {
+			return nil, fmt.Errorf("get digest recipients: %w", err)
+		}
+		rec.WeekStart = domain.CivilDate(rec.WeekStart)
+		out = append(out, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get digest recipients: %w", err)
+	}
+
+	return out, nil
+}
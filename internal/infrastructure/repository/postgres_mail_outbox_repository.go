@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresMailOutboxRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresMailOutboxRepository(db *sql.DB) irepo.MailOutboxRepository {
+	return &PostgresMailOutboxRepository{db: db}
+}
+
+func (r *PostgresMailOutboxRepository) Enqueue(ctx context.Context, m *domain.MailMessage) (bool, error) {
+	if m == nil {
+		return false, fmt.Errorf("enqueue mail: message is nil")
+	}
+
+	const q = `
+		INSERT INTO mail_outbox (user_id, kind, period_start, to_name, to_address, subject, text_body, html_body)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (kind, user_id, period_start) DO NOTHING
+		RETURNING id, status, next_attempt_at, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, q, m.UserID, m.Kind, m.PeriodStart, m.ToName, m.ToAddress, m.Subject, m.Text, m.HTML).
+		Scan(&m.ID, &m.Status, &m.NextAttemptAt, &m.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("enqueue mail: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *PostgresMailOutboxRepository) ClaimDue(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.MailMessage, error) {
+	const q = `
+		UPDATE mail_outbox
+		SET status = 'sending', attempts = attempts + 1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM mail_outbox
+			WHERE status IN ('pending', 'sending') AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, kind, period_start, to_name, to_address, subject, text_body, html_body,
+			status, attempts, last_error, next_attempt_at, created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, now.UTC(), claimedUntil.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim due mail: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.MailMessage, 0)
+	for rows.Next() {
+		var m domain.MailMessage
+		if err := rows.Scan(&m.ID, &m.UserID, &m.Kind, &m.PeriodStart, &m.ToName, &m.ToAddress, &m.Subject, &m.Text, &m.HTML,
+			&m.Status, &m.Attempts, &m.LastError, &m.NextAttemptAt, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("claim due mail: %w", err)
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("claim due mail: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresMailOutboxRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	const q = `
+		UPDATE mail_outbox
+		SET status = 'sent', sent_at = $2, last_error = ''
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, q, id, sentAt.UTC()); err != nil {
+		return fmt.Errorf("mark mail sent: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresMailOutboxRepository) MarkFailed(ctx context.Context, id, reason string, retryAt *time.Time) error {
+	const q = `
+		UPDATE mail_outbox
+		SET status = CASE WHEN $3::timestamp IS NULL THEN 'failed' ELSE 'pending' END,
+			next_attempt_at = COALESCE($3, next_attempt_at),
+			last_error = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, q, id, reason, optionalUTC(retryAt)); err != nil {
+		return fmt.Errorf("mark mail failed: %w", err)
+	}
+
+	return nil
+}
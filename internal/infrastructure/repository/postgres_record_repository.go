@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresRecordRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRecordRepository(db *sql.DB) irepo.RecordRepository {
+	return &PostgresRecordRepository{db: db}
+}
+
+const recordColumns = `pr.id, pr.user_id, pr.exercise_id, e.name, pr.workout_session_id, pr.record_type,
+	pr.value::float8, pr.previous_value::float8, pr.weight::float8, pr.reps, pr.achieved_at`
+
+func (r *PostgresRecordRepository) GetCurrent(ctx context.Context, userID string, exerciseIDs []string) ([]domain.PersonalRecord, error) {
+	// Reps records are kept per weight; the other types have one per exercise.
+	q := `
+		SELECT DISTINCT ON (pr.exercise_id, pr.record_type, CASE WHEN pr.record_type = 'max_reps' THEN pr.weight END)
+			` + recordColumns + `
+		FROM personal_records pr
+		JOIN exercises e ON e.id = pr.exercise_id
+		WHERE pr.user_id = $1 AND ($2::uuid[] IS NULL OR pr.exercise_id = ANY($2::uuid[]))
+		ORDER BY pr.exercise_id, pr.record_type, CASE WHEN pr.record_type = 'max_reps' THEN pr.weight END,
+			pr.value DESC, pr.achieved_at ASC
+	`
+
+	var ids interface{}
+	if exerciseIDs != nil {
+		ids = pq.Array(exerciseIDs)
+	}
+
+	records, err := r.query(ctx, q, userID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get current records: %w", err)
+	}
+	return records, nil
+}
+
+func (r *PostgresRecordRepository) GetHistory(ctx context.Context, userID, exerciseID string) ([]domain.PersonalRecord, error) {
+	q := `
+		SELECT ` + recordColumns + `
+		FROM personal_records pr
+		JOIN exercises e ON e.id = pr.exercise_id
+		WHERE pr.user_id = $1 AND ($2 = '' OR pr.exercise_id::text = $2)
+		ORDER BY pr.achieved_at DESC, e.name, pr.record_type, pr.weight
+	`
+
+	records, err := r.query(ctx, q, userID, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("get record history: %w", err)
+	}
+	return records, nil
+}
+
+func (r *PostgresRecordRepository) GetAchieved(ctx context.Context, userID string, start, end time.Time) ([]domain.PersonalRecord, error) {
+	q := `
+		SELECT ` + recordColumns + `
+		FROM personal_records pr
+		JOIN exercises e ON e.id = pr.exercise_id
+		WHERE pr.user_id = $1 AND pr.achieved_at >= $2 AND pr.achieved_at < $3
+		ORDER BY e.name, pr.record_type, pr.weight, pr.achieved_at
+	`
+
+	records, err := r.query(ctx, q, userID, start.UTC(), end.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("get achieved records: %w", err)
+	}
+	return records, nil
+}
+
+func (r *PostgresRecordRepository) query(ctx context.Context, q string, args ...interface{}) ([]domain.PersonalRecord, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.PersonalRecord, 0)
+	for rows.Next() {
+		var pr domain.PersonalRecord
+		var previous sql.NullFloat64
+		if err := rows.Scan(&pr.ID, &pr.UserID, &pr.ExerciseID, &pr.ExerciseName, &pr.SessionID, &pr.Type,
+			&pr.Value, &previous, &pr.Weight, &pr.Reps, &pr.AchievedAt); err != nil {
+			return nil, err
+		}
+		if previous.Valid {
+			v := previous.Float64
+			pr.Previous = &v
+		}
+		out = append(out, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// insertRecords stores new personal records within tx, filling in their IDs
+// and exercise names.
+func insertRecords(ctx context.Context, tx *sql.Tx, records []domain.PersonalRecord) error {
+	const q = `
+		INSERT INTO personal_records (user_id, exercise_id, workout_session_id, record_type, value, previous_value, weight, reps, achieved_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, (SELECT name FROM exercises WHERE id = exercise_id)
+	`
+
+	for i := range records {
+		pr := &records[i]
+		var previous interface{}
+		if pr.Previous != nil {
+			previous = *pr.Previous
+		}
+		if err := tx.QueryRowContext(ctx, q, pr.UserID, pr.ExerciseID, pr.SessionID, pr.Type, pr.Value, previous, pr.Weight, pr.Reps, pr.AchievedAt).Scan(&pr.ID, &pr.ExerciseName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresReminderRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresReminderRepository(db *sql.DB) irepo.ReminderRepository {
+	return &PostgresReminderRepository{db: db}
+}
+
+func (r *PostgresReminderRepository) GetDue(ctx context.Context, channel string, now time.Time, limit int) ([]domain.Reminder, error) {
+	const q = `
+		SELECT sw.id, sw.user_id, u.name, u.email, sw.workout_plan_id, wp.name, sw.scheduled_at, sw.timezone, sw.all_day,
+			COALESCE(rd.attempts, 0)
+		FROM scheduled_workouts sw
+		JOIN users u ON u.id = sw.user_id
+		JOIN workout_plans wp ON wp.id = sw.workout_plan_id
+		LEFT JOIN reminder_deliveries rd
+			ON rd.scheduled_workout_id = sw.id AND rd.channel = $1 AND rd.scheduled_at = sw.scheduled_at
+		WHERE sw.status = 'pending'
+		AND u.reminder_lead_minutes > 0
+		AND sw.scheduled_at > $2
+		AND sw.scheduled_at - make_interval(mins => u.reminder_lead_minutes) <= $2
+		AND (rd.scheduled_workout_id IS NULL OR rd.next_attempt_at <= $2)
+		ORDER BY COALESCE(rd.attempts, 0), sw.scheduled_at
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, channel, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get due reminders: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.Reminder, 0)
+	for rows.Next() {
+		var rem domain.Reminder
+		if err := rows.Scan(&rem.ScheduleID, &rem.UserID, &rem.UserName, &rem.Email, &rem.WorkoutPlanID, &rem.PlanName, &rem.ScheduledAt, &rem.Timezone, &rem.AllDay, &rem.Attempts); err != nil {
+			return nil, fmt.Errorf("get due reminders: %w", err)
+		}
+		out = append(out, rem)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get due reminders: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresReminderRepository) Claim(ctx context.Context, scheduleID, channel string, scheduledAt, now time.Time) (bool, error) {
+	const q = `
+		INSERT INTO reminder_deliveries (scheduled_workout_id, channel, scheduled_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (scheduled_workout_id, channel, scheduled_at) DO UPDATE
+		SET attempts = reminder_deliveries.attempts + 1, sent_at = now(), failed_at = NULL, next_attempt_at = NULL
+		WHERE reminder_deliveries.next_attempt_at <= $4
+	`
+
+	res, err := r.db.ExecContext(ctx, q, scheduleID, channel, scheduledAt, now)
+	if err != nil {
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim reminder: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+func (r *PostgresReminderRepository) MarkFailed(ctx context.Context, scheduleID, channel string, scheduledAt time.Time, reason string, retryAt *time.Time) error {
+	const q = `
+		UPDATE reminder_deliveries
+		SET failed_at = now(), last_error = $4, next_attempt_at = $5
+		WHERE scheduled_workout_id = $1 AND channel = $2 AND scheduled_at = $3
+	`
+
+	if _, err := r.db.ExecContext(ctx, q, scheduleID, channel, scheduledAt, reason, retryAt); err != nil {
+		return fmt.Errorf("mark reminder failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresReportRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresReportRepository(db *sql.DB) irepo.ReportRepository {
+	return &PostgresReportRepository{db: db}
+}
+
+func (r *PostgresReportRepository) GetDailyStats(ctx context.Context, userID, timezone string, first, last time.Time) ([]domain.DailyStats, error) {
+	sessions, exercises, err := r.dailySources(ctx, userID, timezone, "$4")
+	if err != nil {
+		return nil, fmt.Errorf("get daily stats: %w", err)
+	}
+
+	q := fmt.Sprintf(`
+		WITH d AS (%s), e AS (%s)
+		SELECT d.day, d.sessions, d.duration_seconds, COALESCE(SUM(e.volume), 0)::float8,
+			COALESCE(array_agg(e.exercise_id::text) FILTER (WHERE e.exercise_id IS NOT NULL), '{}')
+		FROM d
+		LEFT JOIN e ON e.day = d.day
+		WHERE d.day BETWEEN $2 AND $3
+		GROUP BY d.day, d.sessions, d.duration_seconds
+		ORDER BY d.day
+	`, sessions, exercises)
+
+	rows, err := r.db.QueryContext(ctx, q, userID, first, last, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("get daily stats: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.DailyStats, 0)
+	for rows.Next() {
+		var s domain.DailyStats
+		var seconds int64
+		var exerciseIDs pq.StringArray
+		if err := rows.Scan(&s.Day, &s.Sessions, &seconds, &s.Volume, &exerciseIDs); err != nil {
+			return nil, fmt.Errorf("get daily stats: %w", err)
+		}
+		s.Day = domain.CivilDate(s.Day)
+		s.Duration = time.Duration(seconds) * time.Second
+		s.ExerciseIDs = exerciseIDs
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get daily stats: %w", err)
+	}
+
+	return out, nil
+}
+
+// dailySources returns the daily session and exercise sources for the user:
+// the rollups when they were built for timezone, the sessions otherwise. tz
+// is the query parameter holding timezone.
+func (r *PostgresReportRepository) dailySources(ctx context.Context, userID, timezone, tz string) (string, string, error) {
+	const q = `SELECT EXISTS (SELECT 1 FROM report_rollup_state WHERE user_id = $1 AND timezone = $2)`
+
+	var ready bool
+	if err := r.db.QueryRowContext(ctx, q, userID, timezone).Scan(&ready); err != nil {
+		return "", "", err
+	}
+	if ready {
+		return fmt.Sprintf(rollupSessionsSQL, tz), fmt.Sprintf(rollupExercisesSQL, tz), nil
+	}
+	return fmt.Sprintf(dailySessionsSQL, tz), fmt.Sprintf(dailyExercisesSQL, tz), nil
+}
+
+func (r *PostgresReportRepository) GetPerformedSets(ctx context.Context, userID, exerciseID string, start, end *time.Time) ([]domain.PerformedSet, error) {
+	// Logged working sets win; an exercise recorded without sets counts as
+	// its sets × reps at the recorded weight.
+	const q = `
+		SELECT ws.id, ws.started_at, ws.completed_at, ps.reps, ps.weight
+		FROM workout_sessions ws
+		JOIN workout_session_exercises wse ON wse.workout_session_id = ws.id
+		JOIN LATERAL (
+			SELECT st.actual_reps AS reps, COALESCE(st.actual_weight, st.target_weight, 0)::float8 AS weight, st.set_number
+			FROM workout_session_sets st
+			WHERE st.workout_session_exercise_id = wse.id
+			AND st.is_warmup = false AND st.completed_at IS NOT NULL AND st.actual_reps > 0
+			UNION ALL
+			SELECT COALESCE(wse.actual_reps, wse.reps), COALESCE(wse.actual_weight, wse.weight, 0)::float8, n
+			FROM generate_series(1, wse.sets) AS n
+			WHERE COALESCE(wse.actual_reps, wse.reps) > 0
+			AND NOT EXISTS (
+				SELECT 1 FROM workout_session_sets st
+				WHERE st.workout_session_exercise_id = wse.id
+				AND st.is_warmup = false AND st.completed_at IS NOT NULL AND st.actual_reps > 0
+			)
+		) ps ON true
+		WHERE ws.user_id = $1 AND wse.exercise_id = $2 AND ws.completed_at IS NOT NULL
+		AND ($3::timestamp IS NULL OR ws.started_at >= $3)
+		AND ($4::timestamp IS NULL OR ws.started_at < $4)
+		ORDER BY ws.started_at, ws.id, ps.set_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, exerciseID, optionalUTC(start), optionalUTC(end))
+	if err != nil {
+		return nil, fmt.Errorf("get performed sets: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.PerformedSet, 0)
+	for rows.Next() {
+		var s domain.PerformedSet
+		if err := rows.Scan(&s.SessionID, &s.StartedAt, &s.CompletedAt, &s.Reps, &s.Weight); err != nil {
+			return nil, fmt.Errorf("get performed sets: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get performed sets: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresReportRepository) GetMuscleWork(ctx context.Context, userID, timezone string, first, last time.Time) ([]domain.MuscleWork, error) {
+	_, exercises, err := r.dailySources(ctx, userID, timezone, "$4")
+	if err != nil {
+		return nil, fmt.Errorf("get muscle work: %w", err)
+	}
+
+	q := fmt.Sprintf(`
+		WITH e AS (%s)
+		SELECT e.day, COALESCE(NULLIF(x.category, ''), $5), COALESCE(NULLIF(x.muscle_group, ''), $5), e.sets, e.volume::float8
+		FROM e
+		JOIN exercises x ON x.id = e.exercise_id
+		WHERE e.day BETWEEN $2 AND $3
+		ORDER BY e.day
+	`, exercises)
+
+	rows, err := r.db.QueryContext(ctx, q, userID, first, last, timezone, domain.UnspecifiedGroup)
+	if err != nil {
+		return nil, fmt.Errorf("get muscle work: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.MuscleWork, 0)
+	for rows.Next() {
+		var w domain.MuscleWork
+		if err := rows.Scan(&w.Day, &w.Category, &w.MuscleGroup, &w.Sets, &w.Volume); err != nil {
+			return nil, fmt.Errorf("get muscle work: %w", err)
+		}
+		w.Day = domain.CivilDate(w.Day)
+		out = append(out, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get muscle work: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresReportRepository) GetScheduleStatuses(ctx context.Context, userID string, first, last time.Time) ([]domain.ScheduledWorkout, error) {
+	const q = `
+		SELECT id, scheduled_date, status
+		FROM scheduled_workouts
+		WHERE user_id = $1 AND scheduled_date BETWEEN $2 AND $3
+		ORDER BY scheduled_date
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, first, last)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule statuses: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.ScheduledWorkout, 0)
+	for rows.Next() {
+		var sw domain.ScheduledWorkout
+		if err := rows.Scan(&sw.ID, &sw.ScheduledDate, &sw.Status); err != nil {
+			return nil, fmt.Errorf("get schedule statuses: %w", err)
+		}
+		out = append(out, sw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get schedule statuses: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresReportRepository) GetTrainingDays(ctx context.Context, userID, timezone string) ([]time.Time, error) {
+	sessions, _, err := r.dailySources(ctx, userID, timezone, "$2")
+	if err != nil {
+		return nil, fmt.Errorf("get training days: %w", err)
+	}
+
+	q := fmt.Sprintf(`WITH d AS (%s) SELECT d.day FROM d ORDER BY d.day`, sessions)
+
+	rows, err := r.db.QueryContext(ctx, q, userID, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("get training days: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]time.Time, 0)
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("get training days: %w", err)
+		}
+		out = append(out, domain.CivilDate(day))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get training days: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresReportRepository) GetSessionLog(ctx context.Context, userID string, start, end *time.Time) ([]domain.SessionLogEntry, error) {
+	const q = `
+		SELECT ws.id, COALESCE(wp.name, ''), ws.started_at, ws.completed_at, COALESCE(e.name, ''),
+			COALESCE(l.set_number, 0), COALESCE(l.reps, 0), COALESCE(l.weight, 0), COALESCE(l.is_warmup, false), l.rpe
+		FROM workout_sessions ws
+		LEFT JOIN workout_plans wp ON wp.id = ws.workout_plan_id
+		LEFT JOIN workout_session_exercises wse ON wse.workout_session_id = ws.id
+		LEFT JOIN exercises e ON e.id = wse.exercise_id
+		LEFT JOIN LATERAL (
+			SELECT st.set_number, st.actual_reps AS reps, COALESCE(st.actual_weight, st.target_weight, 0)::float8 AS weight,
+				st.is_warmup, st.rpe::float8 AS rpe
+			FROM workout_session_sets st
+			WHERE st.workout_session_exercise_id = wse.id AND st.completed_at IS NOT NULL AND st.actual_reps IS NOT NULL
+			UNION ALL
+			SELECT n, COALESCE(wse.actual_reps, wse.reps), COALESCE(wse.actual_weight, wse.weight, 0)::float8, false, NULL::float8
+			FROM generate_series(1, wse.sets) AS n
+			WHERE NOT EXISTS (
+				SELECT 1 FROM workout_session_sets st
+				WHERE st.workout_session_exercise_id = wse.id AND st.completed_at IS NOT NULL AND st.actual_reps IS NOT NULL
+			)
+		) l ON true
+		WHERE ws.user_id = $1 AND ws.completed_at IS NOT NULL
+		AND ($2::timestamp IS NULL OR ws.started_at >= $2)
+		AND ($3::timestamp IS NULL OR ws.started_at < $3)
+		ORDER BY ws.started_at, ws.id, wse.order_index, wse.id, l.set_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, optionalUTC(start), optionalUTC(end))
+	if err != nil {
+		return nil, fmt.Errorf("get session log: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.SessionLogEntry, 0)
+	for rows.Next() {
+		var e domain.SessionLogEntry
+		var rpe sql.NullFloat64
+		if err := rows.Scan(&e.SessionID, &e.PlanName, &e.StartedAt, &e.CompletedAt, &e.ExerciseName,
+			&e.SetNumber, &e.Reps, &e.Weight, &e.IsWarmup, &rpe); err != nil {
+			return nil, fmt.Errorf("get session log: %w", err)
+		}
+		if rpe.Valid {
+			v := rpe.Float64
+			e.RPE = &v
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get session log: %w", err)
+	}
+
+	return out, nil
+}
+
+func optionalUTC(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
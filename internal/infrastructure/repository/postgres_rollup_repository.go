@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	irepo "workout-tracker/internal/repository"
+)
+
+// The daily sources below select user $1. %[1]s is the timezone: a
+// parameter when reading, u.timezone when building the rollups. started_at
+// is stored in UTC without a zone.
+const (
+	dailySessionsSQL = `
+		SELECT ws.user_id, (ws.started_at AT TIME ZONE 'UTC' AT TIME ZONE %[1]s)::date AS day,
+			COUNT(1)::int AS sessions,
+			SUM(EXTRACT(EPOCH FROM GREATEST(ws.completed_at - ws.started_at, interval '0')))::bigint AS duration_seconds
+		FROM workout_sessions ws
+		JOIN users u ON u.id = ws.user_id
+		WHERE ws.user_id = $1 AND ws.completed_at IS NOT NULL
+		GROUP BY 1, 2
+	`
+	// An exercise without logged working sets counts its prescribed sets.
+	dailyExercisesSQL = `
+		SELECT ws.user_id, wse.exercise_id, (ws.started_at AT TIME ZONE 'UTC' AT TIME ZONE %[1]s)::date AS day,
+			COUNT(DISTINCT ws.id)::int AS sessions,
+			SUM(COALESCE(NULLIF(logged.sets, 0), wse.sets))::int AS sets,
+			COALESCE(SUM(CASE WHEN logged.sets > 0 THEN logged.volume
+				ELSE wse.sets * COALESCE(wse.actual_reps, wse.reps) * COALESCE(wse.actual_weight, wse.weight, 0)
+			END), 0) AS volume
+		FROM workout_sessions ws
+		JOIN users u ON u.id = ws.user_id
+		JOIN workout_session_exercises wse ON wse.workout_session_id = ws.id
+		CROSS JOIN LATERAL (
+			SELECT COUNT(1)::int AS sets, COALESCE(SUM(st.actual_reps * COALESCE(st.actual_weight, st.target_weight, 0)), 0) AS volume
+			FROM workout_session_sets st
+			WHERE st.workout_session_exercise_id = wse.id
+			AND st.is_warmup = false AND st.completed_at IS NOT NULL AND st.actual_reps > 0
+		) logged
+		WHERE ws.user_id = $1 AND ws.completed_at IS NOT NULL
+		GROUP BY 1, 2, 3
+	`
+	rollupSessionsSQL = `
+		SELECT r.user_id, r.day, r.sessions, r.duration_seconds
+		FROM report_daily_rollups r
+		JOIN report_rollup_state s ON s.user_id = r.user_id AND s.timezone = %[1]s
+		WHERE r.user_id = $1
+	`
+	rollupExercisesSQL = `
+		SELECT r.user_id, r.exercise_id, r.day, r.sessions, r.sets, r.volume
+		FROM report_daily_exercise_rollups r
+		JOIN report_rollup_state s ON s.user_id = r.user_id AND s.timezone = %[1]s
+		WHERE r.user_id = $1
+	`
+)
+
+type PostgresRollupRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRollupRepository(db *sql.DB) irepo.RollupRepository {
+	return &PostgresRollupRepository{db: db}
+}
+
+func (r *PostgresRollupRepository) RefreshSession(ctx context.Context, userID, sessionID string) error {
+	err := r.inUserTx(ctx, userID, func(tx *sql.Tx) error {
+		const q = `
+			SELECT (ws.started_at AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date, COALESCE(s.timezone = u.timezone, false)
+			FROM workout_sessions ws
+			JOIN users u ON u.id = ws.user_id
+			LEFT JOIN report_rollup_state s ON s.user_id = u.id
+			WHERE ws.id = $1 AND ws.user_id = $2
+		`
+
+		var day time.Time
+		var current bool
+		if err := tx.QueryRowContext(ctx, q, sessionID, userID).Scan(&day, &current); err != nil {
+			return err
+		}
+		if !current {
+			return rebuildRollups(ctx, tx, userID, nil)
+		}
+		return rebuildRollups(ctx, tx, userID, &day)
+	})
+	if err != nil {
+		return fmt.Errorf("refresh session rollups: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRollupRepository) Invalidate(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM report_rollup_state WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("invalidate rollups: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRollupRepository) RebuildUser(ctx context.Context, userID string) error {
+	if err := r.inUserTx(ctx, userID, func(tx *sql.Tx) error {
+		return rebuildRollups(ctx, tx, userID, nil)
+	}); err != nil {
+		return fmt.Errorf("rebuild rollups: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRollupRepository) RebuildAll(ctx context.Context) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM users ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("rebuild all rollups: %w", err)
+	}
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("rebuild all rollups: %w", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rebuild all rollups: %w", err)
+	}
+
+	// One transaction per user keeps locks short on large histories.
+	for i, id := range userIDs {
+		if err := r.inUserTx(ctx, id, func(tx *sql.Tx) error {
+			return rebuildRollups(ctx, tx, id, nil)
+		}); err != nil {
+			return i, fmt.Errorf("rebuild all rollups: %w", err)
+		}
+	}
+
+	return len(userIDs), nil
+}
+
+// inUserTx runs fn in a transaction holding the user's rollup lock, so
+// concurrent refreshes of one user serialize.
+func (r *PostgresRollupRepository) inUserTx(ctx context.Context, userID string, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('report_rollups:' || $1))`, userID); err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// rebuildRollups recomputes the user's rollups for one day, or for all days
+// when day is nil and then records the timezone they were built for.
+func rebuildRollups(ctx context.Context, tx *sql.Tx, userID string, day *time.Time) error {
+	var dayArg interface{}
+	if day != nil {
+		dayArg = day.Format("2006-01-02")
+	}
+
+	stmts := []string{
+		`DELETE FROM report_daily_exercise_rollups WHERE user_id = $1 AND ($2::date IS NULL OR day = $2::date)`,
+		`DELETE FROM report_daily_rollups WHERE user_id = $1 AND ($2::date IS NULL OR day = $2::date)`,
+		fmt.Sprintf(`
+			INSERT INTO report_daily_rollups (user_id, day, sessions, duration_seconds)
+			SELECT d.user_id, d.day, d.sessions, d.duration_seconds
+			FROM (%s) d
+			WHERE $2::date IS NULL OR d.day = $2::date
+		`, fmt.Sprintf(dailySessionsSQL, "u.timezone")),
+		fmt.Sprintf(`
+			INSERT INTO report_daily_exercise_rollups (user_id, exercise_id, day, sessions, sets, volume)
+			SELECT e.user_id, e.exercise_id, e.day, e.sessions, e.sets, e.volume
+			FROM (%s) e
+			WHERE $2::date IS NULL OR e.day = $2::date
+		`, fmt.Sprintf(dailyExercisesSQL, "u.timezone")),
+	}
+	for _, q := range stmts {
+		if _, err := tx.ExecContext(ctx, q, userID, dayArg); err != nil {
+			return err
+		}
+	}
+	if day != nil {
+		return nil
+	}
+
+	const state = `
+		INSERT INTO report_rollup_state (user_id, timezone, built_at)
+		SELECT id, timezone, NOW() FROM users WHERE id = $1
+		ON CONFLICT (user_id) DO UPDATE SET timezone = EXCLUDED.timezone, built_at = EXCLUDED.built_at
+	`
+	_, err := tx.ExecContext(ctx, state, userID)
+	return err
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresScheduleSeriesRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresScheduleSeriesRepository(db *sql.DB) irepo.ScheduleSeriesRepository {
+	return &PostgresScheduleSeriesRepository{db: db}
+}
+
+func (r *PostgresScheduleSeriesRepository) Create(ctx context.Context, s *domain.ScheduleSeries) error {
+	if s == nil {
+		return fmt.Errorf("create schedule series: series is nil")
+	}
+
+	const q = `
+		INSERT INTO schedule_series (user_id, workout_plan_id, starts_at, timezone, all_day, rrule, exdates)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::date[])
+		RETURNING id, created_at
+	`
+
+	if err := r.db.QueryRowContext(ctx, q, s.UserID, s.WorkoutPlanID, s.StartsAt, s.Timezone, s.AllDay, s.RRule, dateArray(s.ExDates)).Scan(&s.ID, &s.CreatedAt); err != nil {
+		return fmt.Errorf("create schedule series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresScheduleSeriesRepository) GetByID(ctx context.Context, id string, userID string) (*domain.ScheduleSeries, error) {
+	const q = `
+		SELECT id, user_id, workout_plan_id, starts_at, timezone, all_day, rrule, exdates::text[], materialized_until, created_at
+		FROM schedule_series
+		WHERE id = $1 AND user_id = $2
+	`
+
+	s, err := scanScheduleSeries(r.db.QueryRowContext(ctx, q, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get schedule series: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *PostgresScheduleSeriesRepository) GetByUser(ctx context.Context, userID string) ([]domain.ScheduleSeries, error) {
+	const q = `
+		SELECT id, user_id, workout_plan_id, starts_at, timezone, all_day, rrule, exdates::text[], materialized_until, created_at
+		FROM schedule_series
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get schedule series by user: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.ScheduleSeries, 0)
+	for rows.Next() {
+		s, err := scanScheduleSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("get schedule series by user: %w", err)
+		}
+		out = append(out, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get schedule series by user: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresScheduleSeriesRepository) GetStaleUserIDs(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	const q = `
+		SELECT user_id
+		FROM schedule_series
+		WHERE materialized_until IS NULL OR materialized_until < $1
+		GROUP BY user_id
+		ORDER BY MIN(COALESCE(materialized_until, '-infinity'::date)), user_id
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get stale series users: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("get stale series users: %w", err)
+		}
+		out = append(out, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get stale series users: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r *PostgresScheduleSeriesRepository) Update(ctx context.Context, s *domain.ScheduleSeries, from time.Time) error {
+	if s == nil {
+		return fmt.Errorf("update schedule series: series is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("update schedule series: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const updateSeries = `
+		UPDATE schedule_series
+		SET workout_plan_id = $1, starts_at = $2, timezone = $3, all_day = $4, rrule = $5,
+			exdates = $6::date[], materialized_until = $7::date - 1, updated_at = now()
+		WHERE id = $8 AND user_id = $9
+	`
+
+	res, err := tx.ExecContext(ctx, updateSeries, s.WorkoutPlanID, s.StartsAt, s.Timezone, s.AllDay, s.RRule, dateArray(s.ExDates), from, s.ID, s.UserID)
+	if err != nil {
+		return fmt.Errorf("update schedule series: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	const deletePending = `
+		DELETE FROM scheduled_workouts
+		WHERE series_id = $1 AND detached = false AND status = 'pending' AND occurrence_date >= $2
+	`
+
+	if _, err := tx.ExecContext(ctx, deletePending, s.ID, from); err != nil {
+		return fmt.Errorf("update schedule series: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("update schedule series: %w", err)
+	}
+
+	prev := from.AddDate(0, 0, -1)
+	s.MaterializedUntil = &prev
+	return nil
+}
+
+func (r *PostgresScheduleSeriesRepository) Delete(ctx context.Context, id string, userID string, from time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("delete schedule series: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const deletePending = `
+		DELETE FROM scheduled_workouts
+		WHERE series_id = $1 AND user_id = $2 AND status = 'pending' AND occurrence_date >= $3
+	`
+
+	if _, err := tx.ExecContext(ctx, deletePending, id, userID, from); err != nil {
+		return fmt.Errorf("delete schedule series: %w", err)
+	}
+
+	const deleteSeries = `
+		DELETE FROM schedule_series
+		WHERE id = $1 AND user_id = $2
+	`
+
+	res, err := tx.ExecContext(ctx, deleteSeries, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete schedule series: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("delete schedule series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresScheduleSeriesRepository) AddOccurrences(ctx context.Context, seriesID string, occurrences []domain.ScheduledWorkout, through time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("add series occurrences: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const insertOccurrence = `
+		INSERT INTO scheduled_workouts (user_id, workout_plan_id, scheduled_date, scheduled_at, timezone, all_day, series_id, occurrence_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING
+	`
+
+	for _, sw := range occurrences {
+		if _, err := tx.ExecContext(ctx, insertOccurrence, sw.UserID, sw.WorkoutPlanID, sw.ScheduledDate, sw.ScheduledAt, sw.Timezone, sw.AllDay, seriesID, sw.OccurrenceDate); err != nil {
+			return fmt.Errorf("add series occurrences: %w", err)
+		}
+	}
+
+	const updateHorizon = `
+		UPDATE schedule_series
+		SET materialized_until = GREATEST(COALESCE(materialized_until, $2::date), $2::date)
+		WHERE id = $1
+	`
+
+	if _, err := tx.ExecContext(ctx, updateHorizon, seriesID, through); err != nil {
+		return fmt.Errorf("add series occurrences: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("add series occurrences: %w", err)
+	}
+
+	return nil
+}
+
+func scanScheduleSeries(row rowScanner) (*domain.ScheduleSeries, error) {
+	var s domain.ScheduleSeries
+	var exdates pq.StringArray
+	var materialized sql.NullTime
+	if err := row.Scan(&s.ID, &s.UserID, &s.WorkoutPlanID, &s.StartsAt, &s.Timezone, &s.AllDay, &s.RRule, &exdates, &materialized, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	for _, d := range exdates {
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return nil, err
+		}
+		s.ExDates = append(s.ExDates, t)
+	}
+	if materialized.Valid {
+		s.MaterializedUntil = &materialized.Time
+	}
+	return &s, nil
+}
+
+func dateArray(dates []time.Time) pq.StringArray {
+	out := make(pq.StringArray, 0, len(dates))
+	for _, d := range dates {
+		out = append(out, d.Format("2006-01-02"))
+	}
+	return out
+}
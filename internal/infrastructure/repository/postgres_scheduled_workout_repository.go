@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"workout-tracker/internal/domain"
 	irepo "workout-tracker/internal/repository"
@@ -23,13 +24,13 @@ func (r *PostgresScheduledWorkoutRepository) Create(ctx context.Context, sw *dom
 	}
 
 	const q = `
-		INSERT INTO scheduled_workouts (user_id, workout_plan_id, scheduled_date)
-		VALUES ($1, $2, $3)
+		INSERT INTO scheduled_workouts (user_id, workout_plan_id, scheduled_date, scheduled_at, timezone, all_day)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (user_id, workout_plan_id, scheduled_date) DO NOTHING
-		RETURNING id, created_at
+		RETURNING id, status, created_at
 	`
 
-	if err := r.db.QueryRowContext(ctx, q, sw.UserID, sw.WorkoutPlanID, sw.ScheduledDate).Scan(&sw.ID, &sw.CreatedAt); err != nil {
+	if err := r.db.QueryRowContext(ctx, q, sw.UserID, sw.WorkoutPlanID, sw.ScheduledDate, sw.ScheduledAt, sw.Timezone, sw.AllDay).Scan(&sw.ID, &sw.Status, &sw.CreatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return sql.ErrNoRows
 		}
@@ -39,36 +40,63 @@ func (r *PostgresScheduledWorkoutRepository) Create(ctx context.Context, sw *dom
 	return nil
 }
 
-func (r *PostgresScheduledWorkoutRepository) GetByUser(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.ScheduledWorkout], error) {
-	offset := (pagination.Page - 1) * pagination.Limit
+// scheduleFilterClause matches the filter arguments built by
+// scheduleFilterArgs, which start at $2 after the user ID.
+const scheduleFilterClause = `
+		sw.user_id = $1
+		AND ($2::date IS NULL OR sw.scheduled_date = $2)
+		AND ($3::date IS NULL OR sw.scheduled_date >= $3)
+		AND ($4::date IS NULL OR sw.scheduled_date <= $4)
+		AND ($5 = '' OR sw.status = $5)
+	`
 
-	var date interface{} = nil
-	if filters.Date != nil {
-		date = *filters.Date
+func scheduleFilterArgs(userID string, filters domain.ScheduledWorkoutFilter) []interface{} {
+	optionalDate := func(t *time.Time) interface{} {
+		if t == nil {
+			return nil
+		}
+		return *t
 	}
+	return []interface{}{userID, optionalDate(filters.Date), optionalDate(filters.From), optionalDate(filters.To), filters.Status}
+}
 
-	const countQ = `
+func scheduleOrder(sort string) string {
+	if sort == domain.SortAsc {
+		return "ORDER BY sw.scheduled_date ASC, sw.scheduled_at ASC, sw.created_at ASC"
+	}
+	return "ORDER BY sw.scheduled_date DESC, sw.scheduled_at DESC, sw.created_at DESC"
+}
+
+func (r *PostgresScheduledWorkoutRepository) countSchedules(ctx context.Context, args []interface{}) (int, error) {
+	countQ := `
 		SELECT COUNT(1)
-		FROM scheduled_workouts
-		WHERE user_id = $1
-		AND ($2::date IS NULL OR scheduled_date = $2)
-	`
+		FROM scheduled_workouts sw
+		WHERE ` + scheduleFilterClause
 
 	var total int
-	if err := r.db.QueryRowContext(ctx, countQ, userID, date).Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, countQ, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *PostgresScheduledWorkoutRepository) GetByUser(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.ScheduledWorkout], error) {
+	offset := (pagination.Page - 1) * pagination.Limit
+	args := scheduleFilterArgs(userID, filters)
+
+	total, err := r.countSchedules(ctx, args)
+	if err != nil {
 		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules by user: %w", err)
 	}
 
-	const q = `
-		SELECT id, user_id, workout_plan_id, scheduled_date, created_at
-		FROM scheduled_workouts
-		WHERE user_id = $1
-		AND ($2::date IS NULL OR scheduled_date = $2)
-		ORDER BY scheduled_date DESC, created_at DESC
-		LIMIT $3 OFFSET $4
+	q := `
+		SELECT sw.id, sw.user_id, sw.workout_plan_id, sw.scheduled_date, sw.scheduled_at, sw.timezone, sw.all_day, sw.status, sw.workout_session_id, sw.cancel_reason, sw.series_id, sw.occurrence_date, sw.detached, sw.created_at
+		FROM scheduled_workouts sw
+		WHERE ` + scheduleFilterClause + scheduleOrder(filters.Sort) + `
+		LIMIT $6 OFFSET $7
 	`
 
-	rows, err := r.db.QueryContext(ctx, q, userID, date, pagination.Limit, offset)
+	rows, err := r.db.QueryContext(ctx, q, append(args, pagination.Limit, offset)...)
 	if err != nil {
 		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules by user: %w", err)
 	}
@@ -76,11 +104,11 @@ func (r *PostgresScheduledWorkoutRepository) GetByUser(ctx context.Context, user
 
 	out := make([]domain.ScheduledWorkout, 0)
 	for rows.Next() {
-		var sw domain.ScheduledWorkout
-		if err := rows.Scan(&sw.ID, &sw.UserID, &sw.WorkoutPlanID, &sw.ScheduledDate, &sw.CreatedAt); err != nil {
+		sw, err := scanScheduledWorkout(rows)
+		if err != nil {
 			return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules by user: %w", err)
 		}
-		out = append(out, sw)
+		out = append(out, *sw)
 	}
 	if err := rows.Err(); err != nil {
 		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules by user: %w", err)
@@ -89,15 +117,135 @@ func (r *PostgresScheduledWorkoutRepository) GetByUser(ctx context.Context, user
 	return domain.NewPaginatedResult(out, total, pagination), nil
 }
 
-func (r *PostgresScheduledWorkoutRepository) Delete(ctx context.Context, id string, userID string) error {
+func (r *PostgresScheduledWorkoutRepository) GetAgenda(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.AgendaItem], error) {
+	offset := (pagination.Page - 1) * pagination.Limit
+	args := scheduleFilterArgs(userID, filters)
+
+	total, err := r.countSchedules(ctx, args)
+	if err != nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+	}
+
+	q := `
+		SELECT sw.id, sw.user_id, sw.workout_plan_id, sw.scheduled_date, sw.scheduled_at, sw.timezone, sw.all_day, sw.status, sw.workout_session_id, sw.cancel_reason, sw.series_id, sw.occurrence_date, sw.detached, sw.created_at, wp.name
+		FROM scheduled_workouts sw
+		JOIN workout_plans wp ON wp.id = sw.workout_plan_id
+		WHERE ` + scheduleFilterClause + scheduleOrder(filters.Sort) + `
+		LIMIT $6 OFFSET $7
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, append(args, pagination.Limit, offset)...)
+	if err != nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.AgendaItem, 0)
+	for rows.Next() {
+		var planName string
+		sw, err := scanScheduledWorkout(rows, &planName)
+		if err != nil {
+			return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+		}
+		out = append(out, domain.AgendaItem{ScheduledWorkout: *sw, PlanName: planName})
+	}
+	if err := rows.Err(); err != nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+	}
+
+	return domain.NewPaginatedResult(out, total, pagination), nil
+}
+
+func (r *PostgresScheduledWorkoutRepository) GetByID(ctx context.Context, id string, userID string) (*domain.ScheduledWorkout, error) {
 	const q = `
-		DELETE FROM scheduled_workouts
+		SELECT id, user_id, workout_plan_id, scheduled_date, scheduled_at, timezone, all_day, status, workout_session_id, cancel_reason, series_id, occurrence_date, detached, created_at
+		FROM scheduled_workouts
 		WHERE id = $1 AND user_id = $2
 	`
 
-	res, err := r.db.ExecContext(ctx, q, id, userID)
+	sw, err := scanScheduledWorkout(r.db.QueryRowContext(ctx, q, id, userID))
 	if err != nil {
-		return fmt.Errorf("delete schedule: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get schedule by id: %w", err)
+	}
+
+	return sw, nil
+}
+
+// markMissedLockKey identifies the missed-marking job among Postgres
+// advisory locks.
+const markMissedLockKey int64 = 0x6d69737365640001
+
+func (r *PostgresScheduledWorkoutRepository) MarkMissed(ctx context.Context, now time.Time, grace time.Duration) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("mark missed schedules: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, markMissedLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("mark missed schedules: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	// A timed schedule ends when it starts; an all-day one at the next local
+	// midnight in its own timezone.
+	const q = `
+		UPDATE scheduled_workouts
+		SET status = 'missed'
+		WHERE status = 'pending'
+		AND CASE
+			WHEN all_day THEN ((scheduled_date + 1)::timestamp AT TIME ZONE timezone)
+			ELSE scheduled_at
+		END + make_interval(secs => $2) < $1
+	`
+
+	res, err := tx.ExecContext(ctx, q, now, grace.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("mark missed schedules: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("mark missed schedules: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("mark missed schedules: %w", err)
+	}
+
+	return int(affected), nil
+}
+
+func (r *PostgresScheduledWorkoutRepository) UpdateStatus(ctx context.Context, sw *domain.ScheduledWorkout) error {
+	if sw == nil {
+		return fmt.Errorf("update schedule status: scheduled workout is nil")
+	}
+
+	const q = `
+		UPDATE scheduled_workouts
+		SET status = $1, workout_session_id = $2, cancel_reason = $3
+		WHERE id = $4 AND user_id = $5
+	`
+
+	var sessionID interface{} = nil
+	if sw.WorkoutSessionID != "" {
+		sessionID = sw.WorkoutSessionID
+	}
+	var reason interface{} = nil
+	if sw.CancelReason != "" {
+		reason = sw.CancelReason
+	}
+
+	res, err := r.db.ExecContext(ctx, q, sw.Status, sessionID, reason, sw.ID, sw.UserID)
+	if err != nil {
+		return fmt.Errorf("update schedule status: %w", err)
 	}
 
 	affected, err := res.RowsAffected()
@@ -107,3 +255,114 @@ func (r *PostgresScheduledWorkoutRepository) Delete(ctx context.Context, id stri
 
 	return nil
 }
+
+func (r *PostgresScheduledWorkoutRepository) GetOccurrence(ctx context.Context, seriesID string, userID string, date time.Time) (*domain.ScheduledWorkout, error) {
+	const q = `
+		SELECT id, user_id, workout_plan_id, scheduled_date, scheduled_at, timezone, all_day, status, workout_session_id, cancel_reason, series_id, occurrence_date, detached, created_at
+		FROM scheduled_workouts
+		WHERE series_id = $1 AND user_id = $2 AND occurrence_date = $3
+	`
+
+	sw, err := scanScheduledWorkout(r.db.QueryRowContext(ctx, q, seriesID, userID, date))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get series occurrence: %w", err)
+	}
+
+	return sw, nil
+}
+
+func (r *PostgresScheduledWorkoutRepository) Update(ctx context.Context, sw *domain.ScheduledWorkout) error {
+	if sw == nil {
+		return fmt.Errorf("update schedule: scheduled workout is nil")
+	}
+
+	const q = `
+		UPDATE scheduled_workouts
+		SET workout_plan_id = $1, scheduled_date = $2, scheduled_at = $3, timezone = $4, all_day = $5, detached = $6
+		WHERE id = $7 AND user_id = $8
+		AND NOT EXISTS (
+			SELECT 1 FROM scheduled_workouts other
+			WHERE other.user_id = $8 AND other.workout_plan_id = $1 AND other.scheduled_date = $2 AND other.id <> $7
+		)
+	`
+
+	res, err := r.db.ExecContext(ctx, q, sw.WorkoutPlanID, sw.ScheduledDate, sw.ScheduledAt, sw.Timezone, sw.AllDay, sw.Detached, sw.ID, sw.UserID)
+	if err != nil {
+		return fmt.Errorf("update schedule: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Delete removes a schedule. Deleting a series occurrence also records its
+// date as an exception so the series does not regenerate it.
+func (r *PostgresScheduledWorkoutRepository) Delete(ctx context.Context, id string, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("delete schedule: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const deleteSchedule = `
+		DELETE FROM scheduled_workouts
+		WHERE id = $1 AND user_id = $2
+		RETURNING series_id, occurrence_date
+	`
+
+	var seriesID sql.NullString
+	var occurrence sql.NullTime
+	if err := tx.QueryRowContext(ctx, deleteSchedule, id, userID).Scan(&seriesID, &occurrence); err != nil {
+		if err == sql.ErrNoRows {
+			return err
+		}
+		return fmt.Errorf("delete schedule: %w", err)
+	}
+
+	if seriesID.Valid && occurrence.Valid {
+		const addExdate = `
+			UPDATE schedule_series
+			SET exdates = array_append(exdates, $2::date)
+			WHERE id = $1 AND NOT ($2::date = ANY(exdates))
+		`
+
+		if _, err := tx.ExecContext(ctx, addExdate, seriesID.String, occurrence.Time); err != nil {
+			return fmt.Errorf("delete schedule: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("delete schedule: %w", err)
+	}
+
+	return nil
+}
+
+// scanScheduledWorkout scans the standard schedule columns followed by any
+// extra destinations for joined columns.
+func scanScheduledWorkout(row rowScanner, extra ...interface{}) (*domain.ScheduledWorkout, error) {
+	var sw domain.ScheduledWorkout
+	var sessionID sql.NullString
+	var reason sql.NullString
+	var seriesID sql.NullString
+	var occurrence sql.NullTime
+	if err := row.Scan(append([]interface{}{&sw.ID, &sw.UserID, &sw.WorkoutPlanID, &sw.ScheduledDate, &sw.ScheduledAt, &sw.Timezone, &sw.AllDay, &sw.Status, &sessionID, &reason, &seriesID, &occurrence, &sw.Detached, &sw.CreatedAt}, extra...)...); err != nil {
+		return nil, err
+	}
+	sw.WorkoutSessionID = sessionID.String
+	sw.CancelReason = reason.String
+	sw.SeriesID = seriesID.String
+	if occurrence.Valid {
+		sw.OccurrenceDate = &occurrence.Time
+	}
+	return &sw, nil
+}
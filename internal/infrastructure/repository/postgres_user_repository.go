@@ -24,11 +24,14 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 	const q = `
 		INSERT INTO users (name, email, password_hash)
 		VALUES ($1, $2, $3)
-		RETURNING id, created_at, updated_at
+		RETURNING id, timezone, reminder_lead_minutes, weekly_digest, created_at, updated_at
 	`
 
 	if err := r.db.QueryRowContext(ctx, q, user.Name, user.Email, user.PasswordHash).Scan(
 		&user.ID,
+		&user.Timezone,
+		&user.ReminderLeadMinutes,
+		&user.WeeklyDigest,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	); err != nil {
@@ -40,7 +43,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 
 func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	const q = `
-		SELECT id, name, email, password_hash, created_at, updated_at
+		SELECT id, name, email, password_hash, timezone, reminder_lead_minutes, weekly_digest, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -51,6 +54,9 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		&u.Name,
 		&u.Email,
 		&u.PasswordHash,
+		&u.Timezone,
+		&u.ReminderLeadMinutes,
+		&u.WeeklyDigest,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	); err != nil {
@@ -65,7 +71,7 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	const q = `
-		SELECT id, name, email, password_hash, created_at, updated_at
+		SELECT id, name, email, password_hash, timezone, reminder_lead_minutes, weekly_digest, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -76,6 +82,9 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domai
 		&u.Name,
 		&u.Email,
 		&u.PasswordHash,
+		&u.Timezone,
+		&u.ReminderLeadMinutes,
+		&u.WeeklyDigest,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	); err != nil {
@@ -87,3 +96,26 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domai
 
 	return &u, nil
 }
+
+func (r *PostgresUserRepository) UpdatePreferences(ctx context.Context, id string, prefs domain.UserPreferencesUpdate) error {
+	const q = `
+		UPDATE users
+		SET timezone = COALESCE($2, timezone),
+			reminder_lead_minutes = COALESCE($3, reminder_lead_minutes),
+			weekly_digest = COALESCE($4, weekly_digest),
+			updated_at = now()
+		WHERE id = $1
+	`
+
+	res, err := r.db.ExecContext(ctx, q, id, prefs.Timezone, prefs.ReminderLeadMinutes, prefs.WeeklyDigest)
+	if err != nil {
+		return fmt.Errorf("update user preferences: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
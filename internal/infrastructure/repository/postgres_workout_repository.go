@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"workout-tracker/internal/domain"
@@ -41,14 +42,25 @@ func (r *PostgresWorkoutRepository) CreatePlan(ctx context.Context, plan *domain
 	}
 
 	const insertPlanExercise = `
-		INSERT INTO workout_plan_exercises (workout_plan_id, exercise_id, sets, reps, weight, order_index)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO workout_plan_exercises (workout_plan_id, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	for _, ex := range exercises {
-		if _, err := tx.ExecContext(ctx, insertPlanExercise, planID, ex.ExerciseID, ex.Sets, ex.Reps, ex.Weight, ex.OrderIndex); err != nil {
+		sets, err := encodeSetPrescriptions(ex.SetPrescriptions)
+		if err != nil {
 			return fmt.Errorf("create plan: %w", err)
 		}
+		args := append([]interface{}{planID, ex.ExerciseID, ex.Sets, ex.Reps, ex.Weight, ex.OrderIndex}, groupArgs(ex.Group)...)
+		args = append(args, ex.TrainingMax, sets)
+		if _, err := tx.ExecContext(ctx, insertPlanExercise, args...); err != nil {
+			return fmt.Errorf("create plan: %w", err)
+		}
+	}
+
+	if err := snapshotPlan(ctx, tx, planID, nil); err != nil {
+		return fmt.Errorf("create plan: %w", err)
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -77,6 +89,7 @@ func (r *PostgresWorkoutRepository) UpdatePlan(ctx context.Context, plan *domain
 		SELECT id
 		FROM workout_plans
 		WHERE id = $1 AND user_id = $2
+		FOR UPDATE
 	`, plan.ID, plan.UserID).Scan(&existingID); err != nil {
 		if err == sql.ErrNoRows {
 			return err
@@ -100,16 +113,27 @@ func (r *PostgresWorkoutRepository) UpdatePlan(ctx context.Context, plan *domain
 	}
 
 	const insertPlanExercise = `
-		INSERT INTO workout_plan_exercises (workout_plan_id, exercise_id, sets, reps, weight, order_index)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO workout_plan_exercises (workout_plan_id, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	for _, ex := range exercises {
-		if _, err := tx.ExecContext(ctx, insertPlanExercise, plan.ID, ex.ExerciseID, ex.Sets, ex.Reps, ex.Weight, ex.OrderIndex); err != nil {
+		sets, err := encodeSetPrescriptions(ex.SetPrescriptions)
+		if err != nil {
+			return fmt.Errorf("update plan: %w", err)
+		}
+		args := append([]interface{}{plan.ID, ex.ExerciseID, ex.Sets, ex.Reps, ex.Weight, ex.OrderIndex}, groupArgs(ex.Group)...)
+		args = append(args, ex.TrainingMax, sets)
+		if _, err := tx.ExecContext(ctx, insertPlanExercise, args...); err != nil {
 			return fmt.Errorf("update plan: %w", err)
 		}
 	}
 
+	if err := snapshotPlan(ctx, tx, plan.ID, nil); err != nil {
+		return fmt.Errorf("update plan: %w", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("update plan: %w", err)
 	}
@@ -180,6 +204,92 @@ func (r *PostgresWorkoutRepository) GetPlanByID(ctx context.Context, id string,
 	return &p, nil
 }
 
+func (r *PostgresWorkoutRepository) GetPlanDetail(ctx context.Context, id string, userID string) (*domain.WorkoutPlanDetail, error) {
+	plan, err := r.GetPlanByID(ctx, id, userID)
+	if err != nil || plan == nil {
+		return nil, err
+	}
+
+	const q = `
+		SELECT wpe.id, wpe.workout_plan_id, wpe.exercise_id, wpe.sets, wpe.reps, wpe.weight, wpe.order_index,
+			wpe.group_id, wpe.group_type, wpe.group_rounds, wpe.group_rest_seconds, wpe.training_max, wpe.set_prescriptions,
+			e.name, e.category, e.muscle_group
+		FROM workout_plan_exercises wpe
+		JOIN exercises e ON e.id = wpe.exercise_id
+		WHERE wpe.workout_plan_id = $1
+		ORDER BY wpe.order_index ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, plan.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get plan detail: %w", err)
+	}
+	defer rows.Close()
+
+	d := &domain.WorkoutPlanDetail{WorkoutPlan: *plan, Exercises: make([]domain.WorkoutPlanExerciseDetail, 0)}
+	for rows.Next() {
+		var ex domain.WorkoutPlanExerciseDetail
+		var weight sql.NullFloat64
+		var g groupColumns
+		var p prescriptionColumns
+		if err := rows.Scan(&ex.ID, &ex.WorkoutPlanID, &ex.ExerciseID, &ex.Sets, &ex.Reps, &weight, &ex.OrderIndex,
+			&g.id, &g.groupType, &g.rounds, &g.restSeconds, &p.trainingMax, &p.sets,
+			&ex.ExerciseName, &ex.Category, &ex.MuscleGroup); err != nil {
+			return nil, fmt.Errorf("get plan detail: %w", err)
+		}
+		ex.Weight = weight.Float64
+		ex.Group = g.group()
+		if err := p.apply(&ex.WorkoutPlanExercise); err != nil {
+			return nil, fmt.Errorf("get plan detail: %w", err)
+		}
+		d.Exercises = append(d.Exercises, ex)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get plan detail: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *PostgresWorkoutRepository) GetPlanExercises(ctx context.Context, planID string) ([]domain.WorkoutPlanExercise, error) {
+	const q = `
+		SELECT id, workout_plan_id, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions
+		FROM workout_plan_exercises
+		WHERE workout_plan_id = $1
+		ORDER BY order_index ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, planID)
+	if err != nil {
+		return nil, fmt.Errorf("get plan exercises: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.WorkoutPlanExercise, 0)
+	for rows.Next() {
+		var ex domain.WorkoutPlanExercise
+		var weight sql.NullFloat64
+		var g groupColumns
+		var p prescriptionColumns
+		if err := rows.Scan(&ex.ID, &ex.WorkoutPlanID, &ex.ExerciseID, &ex.Sets, &ex.Reps, &weight, &ex.OrderIndex,
+			&g.id, &g.groupType, &g.rounds, &g.restSeconds, &p.trainingMax, &p.sets); err != nil {
+			return nil, fmt.Errorf("get plan exercises: %w", err)
+		}
+		ex.Weight = weight.Float64
+		ex.Group = g.group()
+		if err := p.apply(&ex); err != nil {
+			return nil, fmt.Errorf("get plan exercises: %w", err)
+		}
+		out = append(out, ex)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get plan exercises: %w", err)
+	}
+
+	return out, nil
+}
+
 func (r *PostgresWorkoutRepository) DeletePlan(ctx context.Context, id string, userID string) error {
 	const q = `
 		DELETE FROM workout_plans
@@ -194,3 +304,317 @@ func (r *PostgresWorkoutRepository) DeletePlan(ctx context.Context, id string, u
 	_, _ = res.RowsAffected()
 	return nil
 }
+
+func (r *PostgresWorkoutRepository) GetPlanVersions(ctx context.Context, planID string, pagination domain.Pagination) (domain.PaginatedResult[domain.WorkoutPlanVersion], error) {
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(1)
+		FROM workout_plan_versions
+		WHERE workout_plan_id = $1
+	`, planID).Scan(&total); err != nil {
+		return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, fmt.Errorf("get plan versions: %w", err)
+	}
+
+	const q = `
+		SELECT id, workout_plan_id, version, name, notes, restored_from, created_at
+		FROM workout_plan_versions
+		WHERE workout_plan_id = $1
+		ORDER BY version DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, planID, pagination.Limit, offset)
+	if err != nil {
+		return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, fmt.Errorf("get plan versions: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.WorkoutPlanVersion, 0)
+	for rows.Next() {
+		v, err := scanPlanVersion(rows)
+		if err != nil {
+			return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, fmt.Errorf("get plan versions: %w", err)
+		}
+		out = append(out, *v)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, fmt.Errorf("get plan versions: %w", err)
+	}
+
+	return domain.NewPaginatedResult(out, total, pagination), nil
+}
+
+func (r *PostgresWorkoutRepository) GetPlanVersion(ctx context.Context, planID string, version int) (*domain.WorkoutPlanVersion, error) {
+	const q = `
+		SELECT id, workout_plan_id, version, name, notes, restored_from, created_at
+		FROM workout_plan_versions
+		WHERE workout_plan_id = $1 AND version = $2
+	`
+
+	v, err := scanPlanVersion(r.db.QueryRowContext(ctx, q, planID, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get plan version: %w", err)
+	}
+
+	if err := r.loadPlanVersionExercises(ctx, v); err != nil {
+		return nil, fmt.Errorf("get plan version: %w", err)
+	}
+
+	return v, nil
+}
+
+// GetLatestPlanVersion returns nil when the plan has no versions.
+func (r *PostgresWorkoutRepository) GetLatestPlanVersion(ctx context.Context, planID string) (*domain.WorkoutPlanVersion, error) {
+	const q = `
+		SELECT id, workout_plan_id, version, name, notes, restored_from, created_at
+		FROM workout_plan_versions
+		WHERE workout_plan_id = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	v, err := scanPlanVersion(r.db.QueryRowContext(ctx, q, planID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get latest plan version: %w", err)
+	}
+
+	if err := r.loadPlanVersionExercises(ctx, v); err != nil {
+		return nil, fmt.Errorf("get latest plan version: %w", err)
+	}
+
+	return v, nil
+}
+
+func (r *PostgresWorkoutRepository) loadPlanVersionExercises(ctx context.Context, v *domain.WorkoutPlanVersion) error {
+	const exercisesQ = `
+		SELECT ve.id, ve.exercise_id, ve.sets, ve.reps, ve.weight, ve.order_index,
+			ve.group_id, ve.group_type, ve.group_rounds, ve.group_rest_seconds, ve.training_max, ve.set_prescriptions,
+			e.name, e.category, e.muscle_group
+		FROM workout_plan_version_exercises ve
+		JOIN exercises e ON e.id = ve.exercise_id
+		WHERE ve.plan_version_id = $1
+		ORDER BY ve.order_index ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, exercisesQ, v.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	v.Exercises = make([]domain.WorkoutPlanExerciseDetail, 0)
+	for rows.Next() {
+		ex := domain.WorkoutPlanExerciseDetail{WorkoutPlanExercise: domain.WorkoutPlanExercise{WorkoutPlanID: v.WorkoutPlanID}}
+		var weight sql.NullFloat64
+		var g groupColumns
+		var p prescriptionColumns
+		if err := rows.Scan(&ex.ID, &ex.ExerciseID, &ex.Sets, &ex.Reps, &weight, &ex.OrderIndex,
+			&g.id, &g.groupType, &g.rounds, &g.restSeconds, &p.trainingMax, &p.sets,
+			&ex.ExerciseName, &ex.Category, &ex.MuscleGroup); err != nil {
+			return err
+		}
+		ex.Weight = weight.Float64
+		ex.Group = g.group()
+		if err := p.apply(&ex.WorkoutPlanExercise); err != nil {
+			return err
+		}
+		v.Exercises = append(v.Exercises, ex)
+	}
+	return rows.Err()
+}
+
+// RestorePlanVersion makes the plan match one of its earlier versions and
+// records that as a new version. It returns sql.ErrNoRows when the plan or
+// the version does not exist.
+func (r *PostgresWorkoutRepository) RestorePlanVersion(ctx context.Context, planID string, userID string, version int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var versionID string
+	if err := tx.QueryRowContext(ctx, `
+		SELECT v.id
+		FROM workout_plans wp
+		JOIN workout_plan_versions v ON v.workout_plan_id = wp.id AND v.version = $3
+		WHERE wp.id = $1 AND wp.user_id = $2
+		FOR UPDATE OF wp
+	`, planID, userID, version).Scan(&versionID); err != nil {
+		if err == sql.ErrNoRows {
+			return err
+		}
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE workout_plans wp
+		SET name = v.name, notes = v.notes, updated_at = NOW()
+		FROM workout_plan_versions v
+		WHERE wp.id = $1 AND v.id = $2
+	`, planID, versionID); err != nil {
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM workout_plan_exercises
+		WHERE workout_plan_id = $1
+	`, planID); err != nil {
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO workout_plan_exercises (workout_plan_id, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions)
+		SELECT $1::uuid, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions
+		FROM workout_plan_version_exercises
+		WHERE plan_version_id = $2
+	`, planID, versionID); err != nil {
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+
+	if err := snapshotPlan(ctx, tx, planID, &version); err != nil {
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("restore plan version: %w", err)
+	}
+
+	return nil
+}
+
+// snapshotPlan records the plan's current name, notes and exercises as its
+// next version. Callers hold the plan's row lock, or have just created it.
+func snapshotPlan(ctx context.Context, tx *sql.Tx, planID string, restoredFrom *int) error {
+	const insertVersion = `
+		INSERT INTO workout_plan_versions (workout_plan_id, version, name, notes, restored_from)
+		SELECT wp.id,
+			COALESCE((SELECT MAX(v.version) FROM workout_plan_versions v WHERE v.workout_plan_id = wp.id), 0) + 1,
+			wp.name, COALESCE(wp.notes, ''), $2::int
+		FROM workout_plans wp
+		WHERE wp.id = $1
+		RETURNING id
+	`
+
+	var restored interface{}
+	if restoredFrom != nil {
+		restored = *restoredFrom
+	}
+
+	var versionID string
+	if err := tx.QueryRowContext(ctx, insertVersion, planID, restored).Scan(&versionID); err != nil {
+		return err
+	}
+
+	const insertExercises = `
+		INSERT INTO workout_plan_version_exercises (plan_version_id, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions)
+		SELECT $1::uuid, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds, training_max, set_prescriptions
+		FROM workout_plan_exercises
+		WHERE workout_plan_id = $2
+	`
+
+	_, err := tx.ExecContext(ctx, insertExercises, versionID, planID)
+	return err
+}
+
+func scanPlanVersion(row rowScanner) (*domain.WorkoutPlanVersion, error) {
+	var v domain.WorkoutPlanVersion
+	var restoredFrom sql.NullInt64
+	if err := row.Scan(&v.ID, &v.WorkoutPlanID, &v.Version, &v.Name, &v.Notes, &restoredFrom, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	v.RestoredFrom = nullIntPtr(restoredFrom)
+	return &v, nil
+}
+
+// groupArgs returns the group_* column values of an exercise group.
+func groupArgs(g *domain.ExerciseGroup) []interface{} {
+	if g == nil {
+		return []interface{}{nil, nil, nil, nil}
+	}
+	return []interface{}{g.ID, g.Type, g.Rounds, g.RestSeconds}
+}
+
+// groupColumns scans the group_* columns of a plan or session exercise.
+type groupColumns struct {
+	id          sql.NullString
+	groupType   sql.NullString
+	rounds      sql.NullInt64
+	restSeconds sql.NullInt64
+}
+
+func (c groupColumns) group() *domain.ExerciseGroup {
+	if !c.id.Valid {
+		return nil
+	}
+	return &domain.ExerciseGroup{
+		ID:          c.id.String,
+		Type:        c.groupType.String,
+		Rounds:      int(c.rounds.Int64),
+		RestSeconds: int(c.restSeconds.Int64),
+	}
+}
+
+// setPrescriptionJSON is how a set prescription is stored in the
+// set_prescriptions column.
+type setPrescriptionJSON struct {
+	Type    string   `json:"type"`
+	Reps    int      `json:"reps"`
+	RepsMax int      `json:"reps_max,omitempty"`
+	Weight  *float64 `json:"weight,omitempty"`
+	Percent *float64 `json:"percent,omitempty"`
+	RPE     *float64 `json:"rpe,omitempty"`
+}
+
+func encodeSetPrescriptions(ps []domain.SetPrescription) (interface{}, error) {
+	if len(ps) == 0 {
+		return nil, nil
+	}
+	out := make([]setPrescriptionJSON, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, setPrescriptionJSON(p))
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// prescriptionColumns scans the training_max and set_prescriptions columns
+// of a plan exercise.
+type prescriptionColumns struct {
+	trainingMax sql.NullFloat64
+	sets        []byte
+}
+
+func (c prescriptionColumns) apply(ex *domain.WorkoutPlanExercise) error {
+	ex.TrainingMax = nullFloatPtr(c.trainingMax)
+	if len(c.sets) == 0 {
+		return nil
+	}
+
+	var stored []setPrescriptionJSON
+	if err := json.Unmarshal(c.sets, &stored); err != nil {
+		return err
+	}
+	ex.SetPrescriptions = make([]domain.SetPrescription, 0, len(stored))
+	for _, p := range stored {
+		ex.SetPrescriptions = append(ex.SetPrescriptions, domain.SetPrescription(p))
+	}
+	return nil
+}
@@ -0,0 +1,484 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	irepo "workout-tracker/internal/repository"
+)
+
+type PostgresWorkoutSessionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresWorkoutSessionRepository(db *sql.DB) irepo.WorkoutSessionRepository {
+	return &PostgresWorkoutSessionRepository{db: db}
+}
+
+func (r *PostgresWorkoutSessionRepository) Create(ctx context.Context, s *domain.WorkoutSession) error {
+	if s == nil {
+		return fmt.Errorf("create session: session is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const insertSession = `
+		INSERT INTO workout_sessions (user_id, workout_plan_id, started_at, notes, plan_version)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var planID interface{} = nil
+	if s.WorkoutPlanID != "" {
+		planID = s.WorkoutPlanID
+	}
+	var planVersion interface{} = nil
+	if s.PlanVersion > 0 {
+		planVersion = s.PlanVersion
+	}
+
+	var sessionID string
+	if err := tx.QueryRowContext(ctx, insertSession, s.UserID, planID, s.StartedAt, s.Notes, planVersion).Scan(&sessionID); err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+
+	for i := range s.Exercises {
+		s.Exercises[i].WorkoutSessionID = sessionID
+		if err := insertSessionExercise(ctx, tx, &s.Exercises[i]); err != nil {
+			return fmt.Errorf("create session: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+
+	s.ID = sessionID
+	return nil
+}
+
+func (r *PostgresWorkoutSessionRepository) GetByID(ctx context.Context, id string, userID string) (*domain.WorkoutSession, error) {
+	const q = `
+		SELECT id, user_id, workout_plan_id, plan_version, started_at, completed_at, notes
+		FROM workout_sessions
+		WHERE id = $1 AND user_id = $2
+	`
+
+	s, err := scanSession(r.db.QueryRowContext(ctx, q, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get session by id: %w", err)
+	}
+
+	const exercisesQ = `
+		SELECT id, workout_session_id, exercise_id, sets, reps, weight, actual_reps, actual_weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds
+		FROM workout_session_exercises
+		WHERE workout_session_id = $1
+		ORDER BY order_index ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, exercisesQ, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get session by id: %w", err)
+	}
+	defer rows.Close()
+
+	s.Exercises = make([]domain.WorkoutSessionExercise, 0)
+	for rows.Next() {
+		var ex domain.WorkoutSessionExercise
+		var weight sql.NullFloat64
+		var actualReps sql.NullInt64
+		var actualWeight sql.NullFloat64
+		var g groupColumns
+		if err := rows.Scan(&ex.ID, &ex.WorkoutSessionID, &ex.ExerciseID, &ex.Sets, &ex.Reps, &weight, &actualReps, &actualWeight, &ex.OrderIndex,
+			&g.id, &g.groupType, &g.rounds, &g.restSeconds); err != nil {
+			return nil, fmt.Errorf("get session by id: %w", err)
+		}
+		ex.Group = g.group()
+		ex.Weight = weight.Float64
+		ex.ActualReps = nullIntPtr(actualReps)
+		ex.ActualWeight = nullFloatPtr(actualWeight)
+		ex.SetLogs = make([]domain.WorkoutSessionSet, 0)
+		s.Exercises = append(s.Exercises, ex)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get session by id: %w", err)
+	}
+
+	if err := r.loadSets(ctx, s); err != nil {
+		return nil, fmt.Errorf("get session by id: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *PostgresWorkoutSessionRepository) loadSets(ctx context.Context, s *domain.WorkoutSession) error {
+	const q = `
+		SELECT st.id, st.workout_session_exercise_id, st.set_number, st.target_reps, st.target_weight,
+			st.actual_reps, st.actual_weight, st.rpe, st.rir, st.is_warmup, st.completed_at,
+			st.set_type, st.target_reps_max, st.target_rpe
+		FROM workout_session_sets st
+		JOIN workout_session_exercises se ON se.id = st.workout_session_exercise_id
+		WHERE se.workout_session_id = $1
+		ORDER BY st.set_number ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, s.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byExercise := make(map[string]int, len(s.Exercises))
+	for i, ex := range s.Exercises {
+		byExercise[ex.ID] = i
+	}
+
+	for rows.Next() {
+		st, err := scanSessionSet(rows)
+		if err != nil {
+			return err
+		}
+		if i, ok := byExercise[st.WorkoutSessionExerciseID]; ok {
+			s.Exercises[i].SetLogs = append(s.Exercises[i].SetLogs, *st)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *PostgresWorkoutSessionRepository) GetByUser(ctx context.Context, userID string, pagination domain.Pagination, filters domain.WorkoutSessionFilter) (domain.PaginatedResult[domain.WorkoutSession], error) {
+	offset := (pagination.Page - 1) * pagination.Limit
+
+	const countQ = `
+		SELECT COUNT(1)
+		FROM workout_sessions
+		WHERE user_id = $1
+		AND (
+			$2 = ''
+			OR ($2 = 'in_progress' AND completed_at IS NULL)
+			OR ($2 = 'completed' AND completed_at IS NOT NULL)
+		)
+	`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, userID, filters.Status).Scan(&total); err != nil {
+		return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions by user: %w", err)
+	}
+
+	const q = `
+		SELECT id, user_id, workout_plan_id, plan_version, started_at, completed_at, notes
+		FROM workout_sessions
+		WHERE user_id = $1
+		AND (
+			$2 = ''
+			OR ($2 = 'in_progress' AND completed_at IS NULL)
+			OR ($2 = 'completed' AND completed_at IS NOT NULL)
+		)
+		ORDER BY started_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, filters.Status, pagination.Limit, offset)
+	if err != nil {
+		return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions by user: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]domain.WorkoutSession, 0)
+	for rows.Next() {
+		s, err := scanSession(rows)
+		if err != nil {
+			return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions by user: %w", err)
+		}
+		out = append(out, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions by user: %w", err)
+	}
+
+	return domain.NewPaginatedResult(out, total, pagination), nil
+}
+
+func (r *PostgresWorkoutSessionRepository) AddExercise(ctx context.Context, ex *domain.WorkoutSessionExercise) error {
+	if ex == nil {
+		return fmt.Errorf("add session exercise: exercise is nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("add session exercise: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(order_index) + 1, 0)
+		FROM workout_session_exercises
+		WHERE workout_session_id = $1
+	`, ex.WorkoutSessionID).Scan(&ex.OrderIndex); err != nil {
+		return fmt.Errorf("add session exercise: %w", err)
+	}
+
+	if err := insertSessionExercise(ctx, tx, ex); err != nil {
+		return fmt.Errorf("add session exercise: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("add session exercise: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresWorkoutSessionRepository) UpdateExerciseActuals(ctx context.Context, sessionID string, ex *domain.WorkoutSessionExercise) error {
+	if ex == nil {
+		return fmt.Errorf("update session exercise: exercise is nil")
+	}
+
+	const q = `
+		UPDATE workout_session_exercises
+		SET actual_reps = $1, actual_weight = $2
+		WHERE id = $3 AND workout_session_id = $4
+	`
+
+	res, err := r.db.ExecContext(ctx, q, ex.ActualReps, ex.ActualWeight, ex.ID, sessionID)
+	if err != nil {
+		return fmt.Errorf("update session exercise: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresWorkoutSessionRepository) AddSet(ctx context.Context, set *domain.WorkoutSessionSet) error {
+	if set == nil {
+		return fmt.Errorf("add session set: set is nil")
+	}
+
+	const q = `
+		INSERT INTO workout_session_sets (
+			workout_session_exercise_id, set_number, target_reps, target_weight,
+			actual_reps, actual_weight, rpe, rir, is_warmup, completed_at
+		)
+		SELECT $1, COALESCE(MAX(set_number), 0) + 1, $2, $3, $4, $5, $6, $7, $8, $9
+		FROM workout_session_sets
+		WHERE workout_session_exercise_id = $1
+		RETURNING id, set_number
+	`
+
+	if err := r.db.QueryRowContext(ctx, q,
+		set.WorkoutSessionExerciseID,
+		set.TargetReps,
+		set.TargetWeight,
+		set.ActualReps,
+		set.ActualWeight,
+		set.RPE,
+		set.RIR,
+		set.IsWarmup,
+		set.CompletedAt,
+	).Scan(&set.ID, &set.SetNumber); err != nil {
+		return fmt.Errorf("add session set: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresWorkoutSessionRepository) UpdateSet(ctx context.Context, set *domain.WorkoutSessionSet) error {
+	if set == nil {
+		return fmt.Errorf("update session set: set is nil")
+	}
+
+	const q = `
+		UPDATE workout_session_sets
+		SET actual_reps = $1, actual_weight = $2, rpe = $3, rir = $4, is_warmup = $5, completed_at = $6,
+			set_type = NULLIF($9, '')
+		WHERE id = $7 AND workout_session_exercise_id = $8
+	`
+
+	res, err := r.db.ExecContext(ctx, q,
+		set.ActualReps,
+		set.ActualWeight,
+		set.RPE,
+		set.RIR,
+		set.IsWarmup,
+		set.CompletedAt,
+		set.ID,
+		set.WorkoutSessionExerciseID,
+		set.SetType,
+	)
+	if err != nil {
+		return fmt.Errorf("update session set: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresWorkoutSessionRepository) Finish(ctx context.Context, id string, userID string, completedAt time.Time, notes string, records []domain.PersonalRecord) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("finish session: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const q = `
+		UPDATE workout_sessions
+		SET completed_at = $1, notes = $2
+		WHERE id = $3 AND user_id = $4 AND completed_at IS NULL
+	`
+
+	res, err := tx.ExecContext(ctx, q, completedAt, notes, id, userID)
+	if err != nil {
+		return fmt.Errorf("finish session: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := insertRecords(ctx, tx, records); err != nil {
+		return fmt.Errorf("finish session: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("finish session: %w", err)
+	}
+
+	return nil
+}
+
+func insertSessionExercise(ctx context.Context, tx *sql.Tx, ex *domain.WorkoutSessionExercise) error {
+	const insertExercise = `
+		INSERT INTO workout_session_exercises (workout_session_id, exercise_id, sets, reps, weight, order_index,
+			group_id, group_type, group_rounds, group_rest_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`
+
+	const insertSet = `
+		INSERT INTO workout_session_sets (workout_session_exercise_id, set_number, target_reps, target_weight, is_warmup,
+			set_type, target_reps_max, target_rpe)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8)
+		RETURNING id
+	`
+
+	args := append([]interface{}{ex.WorkoutSessionID, ex.ExerciseID, ex.Sets, ex.Reps, ex.Weight, ex.OrderIndex}, groupArgs(ex.Group)...)
+	if err := tx.QueryRowContext(ctx, insertExercise, args...).Scan(&ex.ID); err != nil {
+		return err
+	}
+
+	for i := range ex.SetLogs {
+		st := &ex.SetLogs[i]
+		if err := tx.QueryRowContext(ctx, insertSet, ex.ID, st.SetNumber, st.TargetReps, st.TargetWeight, st.IsWarmup,
+			st.SetType, st.TargetRepsMax, st.TargetRPE).Scan(&st.ID); err != nil {
+			return err
+		}
+		st.WorkoutSessionExerciseID = ex.ID
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row rowScanner) (*domain.WorkoutSession, error) {
+	var s domain.WorkoutSession
+	var planID sql.NullString
+	var planVersion sql.NullInt64
+	var completedAt sql.NullTime
+	var notes sql.NullString
+	if err := row.Scan(&s.ID, &s.UserID, &planID, &planVersion, &s.StartedAt, &completedAt, &notes); err != nil {
+		return nil, err
+	}
+	s.WorkoutPlanID = planID.String
+	s.PlanVersion = int(planVersion.Int64)
+	if completedAt.Valid {
+		t := completedAt.Time
+		s.CompletedAt = &t
+	}
+	s.Notes = notes.String
+	return &s, nil
+}
+
+func scanSessionSet(row rowScanner) (*domain.WorkoutSessionSet, error) {
+	var st domain.WorkoutSessionSet
+	var targetReps, targetRepsMax, actualReps, rir sql.NullInt64
+	var targetWeight, targetRPE, actualWeight, rpe sql.NullFloat64
+	var completedAt sql.NullTime
+	var setType sql.NullString
+	if err := row.Scan(
+		&st.ID,
+		&st.WorkoutSessionExerciseID,
+		&st.SetNumber,
+		&targetReps,
+		&targetWeight,
+		&actualReps,
+		&actualWeight,
+		&rpe,
+		&rir,
+		&st.IsWarmup,
+		&completedAt,
+		&setType,
+		&targetRepsMax,
+		&targetRPE,
+	); err != nil {
+		return nil, err
+	}
+	st.SetType = setType.String
+	st.TargetRepsMax = nullIntPtr(targetRepsMax)
+	st.TargetRPE = nullFloatPtr(targetRPE)
+	st.TargetReps = nullIntPtr(targetReps)
+	st.TargetWeight = nullFloatPtr(targetWeight)
+	st.ActualReps = nullIntPtr(actualReps)
+	st.ActualWeight = nullFloatPtr(actualWeight)
+	st.RPE = nullFloatPtr(rpe)
+	st.RIR = nullIntPtr(rir)
+	if completedAt.Valid {
+		t := completedAt.Time
+		st.CompletedAt = &t
+	}
+	return &st, nil
+}
+
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
+
+func nullFloatPtr(v sql.NullFloat64) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	f := v.Float64
+	return &f
+}
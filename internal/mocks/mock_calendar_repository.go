@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarRepository) SaveFeedToken(ctx context.Context, userID string, tokenHash string) error {
+	args := m.Called(ctx, userID, tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockCalendarRepository) GetFeedUserID(ctx context.Context, tokenHash string) (string, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCalendarRepository) DeleteFeedToken(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockCalendarRepository) GetEntries(ctx context.Context, userID string, from time.Time) ([]domain.CalendarEntry, error) {
+	args := m.Called(ctx, userID, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.CalendarEntry), args.Error(1)
+}
+
+func (m *MockCalendarRepository) GetSchedulesBetween(ctx context.Context, userID string, from, to time.Time) ([]domain.AgendaItem, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AgendaItem), args.Error(1)
+}
+
+func (m *MockCalendarRepository) GetCompletedSessions(ctx context.Context, userID string, start, end time.Time) ([]domain.WorkoutSession, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WorkoutSession), args.Error(1)
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockDigestRepository struct {
+	mock.Mock
+}
+
+func (m *MockDigestRepository) GetDueRecipients(ctx context.Context, now time.Time, after string, limit int) ([]domain.DigestRecipient, error) {
+	args := m.Called(ctx, now, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DigestRecipient), args.Error(1)
+}
+
+type MockDigestRenderer struct {
+	mock.Mock
+}
+
+func (m *MockDigestRenderer) RenderDigest(d domain.WeeklyDigest) (domain.MailContent, error) {
+	args := m.Called(d)
+	return args.Get(0).(domain.MailContent), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockExerciseRepository struct {
+	mock.Mock
+}
+
+func (m *MockExerciseRepository) GetAll(ctx context.Context) ([]domain.Exercise, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Exercise), args.Error(1)
+}
+
+func (m *MockExerciseRepository) GetByID(ctx context.Context, id string) (*domain.Exercise, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Exercise), args.Error(1)
+}
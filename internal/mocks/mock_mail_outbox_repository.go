@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockMailOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockMailOutboxRepository) Enqueue(ctx context.Context, msg *domain.MailMessage) (bool, error) {
+	args := m.Called(ctx, msg)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMailOutboxRepository) ClaimDue(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.MailMessage, error) {
+	args := m.Called(ctx, now, claimedUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.MailMessage), args.Error(1)
+}
+
+func (m *MockMailOutboxRepository) MarkSent(ctx context.Context, id string, sentAt time.Time) error {
+	args := m.Called(ctx, id, sentAt)
+	return args.Error(0)
+}
+
+func (m *MockMailOutboxRepository) MarkFailed(ctx context.Context, id, reason string, retryAt *time.Time) error {
+	args := m.Called(ctx, id, reason, retryAt)
+	return args.Error(0)
+}
+
+type MockMailTransport struct {
+	mock.Mock
+}
+
+func (m *MockMailTransport) Send(ctx context.Context, msg domain.MailMessage) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecordRepository) GetCurrent(ctx context.Context, userID string, exerciseIDs []string) ([]domain.PersonalRecord, error) {
+	args := m.Called(ctx, userID, exerciseIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PersonalRecord), args.Error(1)
+}
+
+func (m *MockRecordRepository) GetHistory(ctx context.Context, userID, exerciseID string) ([]domain.PersonalRecord, error) {
+	args := m.Called(ctx, userID, exerciseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PersonalRecord), args.Error(1)
+}
+
+func (m *MockRecordRepository) GetAchieved(ctx context.Context, userID string, start, end time.Time) ([]domain.PersonalRecord, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PersonalRecord), args.Error(1)
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) GetDue(ctx context.Context, channel string, now time.Time, limit int) ([]domain.Reminder, error) {
+	args := m.Called(ctx, channel, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Claim(ctx context.Context, scheduleID, channel string, scheduledAt, now time.Time) (bool, error) {
+	args := m.Called(ctx, scheduleID, channel, scheduledAt, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReminderRepository) MarkFailed(ctx context.Context, scheduleID, channel string, scheduledAt time.Time, reason string, retryAt *time.Time) error {
+	args := m.Called(ctx, scheduleID, channel, scheduledAt, reason, retryAt)
+	return args.Error(0)
+}
+
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Channel() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, r domain.Reminder) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
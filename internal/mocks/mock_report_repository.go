@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) GetDailyStats(ctx context.Context, userID, timezone string, first, last time.Time) ([]domain.DailyStats, error) {
+	args := m.Called(ctx, userID, timezone, first, last)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.DailyStats), args.Error(1)
+}
+
+func (m *MockReportRepository) GetPerformedSets(ctx context.Context, userID, exerciseID string, start, end *time.Time) ([]domain.PerformedSet, error) {
+	args := m.Called(ctx, userID, exerciseID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PerformedSet), args.Error(1)
+}
+
+func (m *MockReportRepository) GetMuscleWork(ctx context.Context, userID, timezone string, first, last time.Time) ([]domain.MuscleWork, error) {
+	args := m.Called(ctx, userID, timezone, first, last)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.MuscleWork), args.Error(1)
+}
+
+func (m *MockReportRepository) GetScheduleStatuses(ctx context.Context, userID string, first, last time.Time) ([]domain.ScheduledWorkout, error) {
+	args := m.Called(ctx, userID, first, last)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ScheduledWorkout), args.Error(1)
+}
+
+func (m *MockReportRepository) GetTrainingDays(ctx context.Context, userID, timezone string) ([]time.Time, error) {
+	args := m.Called(ctx, userID, timezone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockReportRepository) GetSessionLog(ctx context.Context, userID string, start, end *time.Time) ([]domain.SessionLogEntry, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SessionLogEntry), args.Error(1)
+}
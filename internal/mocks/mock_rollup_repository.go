@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRollupRepository struct {
+	mock.Mock
+}
+
+func (m *MockRollupRepository) RefreshSession(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockRollupRepository) Invalidate(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRollupRepository) RebuildUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockRollupRepository) RebuildAll(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
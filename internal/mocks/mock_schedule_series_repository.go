@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockScheduleSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduleSeriesRepository) Create(ctx context.Context, s *domain.ScheduleSeries) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockScheduleSeriesRepository) GetByID(ctx context.Context, id string, userID string) (*domain.ScheduleSeries, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduleSeries), args.Error(1)
+}
+
+func (m *MockScheduleSeriesRepository) GetByUser(ctx context.Context, userID string) ([]domain.ScheduleSeries, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ScheduleSeries), args.Error(1)
+}
+
+func (m *MockScheduleSeriesRepository) GetStaleUserIDs(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	args := m.Called(ctx, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockScheduleSeriesRepository) Update(ctx context.Context, s *domain.ScheduleSeries, from time.Time) error {
+	args := m.Called(ctx, s, from)
+	return args.Error(0)
+}
+
+func (m *MockScheduleSeriesRepository) Delete(ctx context.Context, id string, userID string, from time.Time) error {
+	args := m.Called(ctx, id, userID, from)
+	return args.Error(0)
+}
+
+func (m *MockScheduleSeriesRepository) AddOccurrences(ctx context.Context, seriesID string, occurrences []domain.ScheduledWorkout, through time.Time) error {
+	args := m.Called(ctx, seriesID, occurrences, through)
+	return args.Error(0)
+}
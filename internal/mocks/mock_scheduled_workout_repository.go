@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -25,6 +26,45 @@ func (m *MockScheduledWorkoutRepository) GetByUser(ctx context.Context, userID s
 	return args.Get(0).(domain.PaginatedResult[domain.ScheduledWorkout]), args.Error(1)
 }
 
+func (m *MockScheduledWorkoutRepository) GetAgenda(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.AgendaItem], error) {
+	args := m.Called(ctx, userID, pagination, filters)
+	if args.Get(0) == nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, args.Error(1)
+	}
+	return args.Get(0).(domain.PaginatedResult[domain.AgendaItem]), args.Error(1)
+}
+
+func (m *MockScheduledWorkoutRepository) GetByID(ctx context.Context, id string, userID string) (*domain.ScheduledWorkout, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduledWorkout), args.Error(1)
+}
+
+func (m *MockScheduledWorkoutRepository) GetOccurrence(ctx context.Context, seriesID string, userID string, date time.Time) (*domain.ScheduledWorkout, error) {
+	args := m.Called(ctx, seriesID, userID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduledWorkout), args.Error(1)
+}
+
+func (m *MockScheduledWorkoutRepository) Update(ctx context.Context, sw *domain.ScheduledWorkout) error {
+	args := m.Called(ctx, sw)
+	return args.Error(0)
+}
+
+func (m *MockScheduledWorkoutRepository) MarkMissed(ctx context.Context, now time.Time, grace time.Duration) (int, error) {
+	args := m.Called(ctx, now, grace)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockScheduledWorkoutRepository) UpdateStatus(ctx context.Context, sw *domain.ScheduledWorkout) error {
+	args := m.Called(ctx, sw)
+	return args.Error(0)
+}
+
 func (m *MockScheduledWorkoutRepository) Delete(ctx context.Context, id string, userID string) error {
 	args := m.Called(ctx, id, userID)
 	return args.Error(0)
@@ -32,3 +32,8 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.Us
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
+
+func (m *MockUserRepository) UpdatePreferences(ctx context.Context, id string, prefs domain.UserPreferencesUpdate) error {
+	args := m.Called(ctx, id, prefs)
+	return args.Error(0)
+}
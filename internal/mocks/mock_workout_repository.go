@@ -38,7 +38,52 @@ func (m *MockWorkoutRepository) GetPlanByID(ctx context.Context, id string, user
 	return args.Get(0).(*domain.WorkoutPlan), args.Error(1)
 }
 
+func (m *MockWorkoutRepository) GetPlanDetail(ctx context.Context, id string, userID string) (*domain.WorkoutPlanDetail, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WorkoutPlanDetail), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) GetPlanExercises(ctx context.Context, planID string) ([]domain.WorkoutPlanExercise, error) {
+	args := m.Called(ctx, planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WorkoutPlanExercise), args.Error(1)
+}
+
 func (m *MockWorkoutRepository) DeletePlan(ctx context.Context, id string, userID string) error {
 	args := m.Called(ctx, id, userID)
 	return args.Error(0)
 }
+
+func (m *MockWorkoutRepository) GetPlanVersions(ctx context.Context, planID string, pagination domain.Pagination) (domain.PaginatedResult[domain.WorkoutPlanVersion], error) {
+	args := m.Called(ctx, planID, pagination)
+	if args.Get(0) == nil {
+		return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, args.Error(1)
+	}
+	return args.Get(0).(domain.PaginatedResult[domain.WorkoutPlanVersion]), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) GetPlanVersion(ctx context.Context, planID string, version int) (*domain.WorkoutPlanVersion, error) {
+	args := m.Called(ctx, planID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WorkoutPlanVersion), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) GetLatestPlanVersion(ctx context.Context, planID string) (*domain.WorkoutPlanVersion, error) {
+	args := m.Called(ctx, planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WorkoutPlanVersion), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) RestorePlanVersion(ctx context.Context, planID string, userID string, version int) error {
+	args := m.Called(ctx, planID, userID, version)
+	return args.Error(0)
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker/internal/domain"
+)
+
+type MockWorkoutSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkoutSessionRepository) Create(ctx context.Context, s *domain.WorkoutSession) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockWorkoutSessionRepository) GetByID(ctx context.Context, id string, userID string) (*domain.WorkoutSession, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WorkoutSession), args.Error(1)
+}
+
+func (m *MockWorkoutSessionRepository) GetByUser(ctx context.Context, userID string, pagination domain.Pagination, filters domain.WorkoutSessionFilter) (domain.PaginatedResult[domain.WorkoutSession], error) {
+	args := m.Called(ctx, userID, pagination, filters)
+	if args.Get(0) == nil {
+		return domain.PaginatedResult[domain.WorkoutSession]{}, args.Error(1)
+	}
+	return args.Get(0).(domain.PaginatedResult[domain.WorkoutSession]), args.Error(1)
+}
+
+func (m *MockWorkoutSessionRepository) AddExercise(ctx context.Context, ex *domain.WorkoutSessionExercise) error {
+	args := m.Called(ctx, ex)
+	return args.Error(0)
+}
+
+func (m *MockWorkoutSessionRepository) UpdateExerciseActuals(ctx context.Context, sessionID string, ex *domain.WorkoutSessionExercise) error {
+	args := m.Called(ctx, sessionID, ex)
+	return args.Error(0)
+}
+
+func (m *MockWorkoutSessionRepository) AddSet(ctx context.Context, set *domain.WorkoutSessionSet) error {
+	args := m.Called(ctx, set)
+	return args.Error(0)
+}
+
+func (m *MockWorkoutSessionRepository) UpdateSet(ctx context.Context, set *domain.WorkoutSessionSet) error {
+	args := m.Called(ctx, set)
+	return args.Error(0)
+}
+
+func (m *MockWorkoutSessionRepository) Finish(ctx context.Context, id string, userID string, completedAt time.Time, notes string, records []domain.PersonalRecord) error {
+	args := m.Called(ctx, id, userID, completedAt, notes, records)
+	return args.Error(0)
+}
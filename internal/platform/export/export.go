@@ -0,0 +1,367 @@
+// Package export renders tabular reports as CSV or as PDF documents with
+// tables and line charts.
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/platform/pdf"
+)
+
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+func IsValidFormat(format string) bool {
+	return format == FormatCSV || format == FormatPDF
+}
+
+func ContentType(format string) string {
+	if format == FormatPDF {
+		return "application/pdf"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type Document struct {
+	Title    string
+	Subtitle string
+	Charts   []Chart
+	Tables   []Table
+}
+
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+}
+
+// Chart plots series against shared x labels. NaN values leave gaps.
+type Chart struct {
+	Title  string
+	Labels []string
+	Series []Series
+}
+
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// Write renders doc in format, which must be valid.
+func Write(w io.Writer, format string, doc Document) error {
+	if format == FormatPDF {
+		return WritePDF(w, doc)
+	}
+	return WriteCSV(w, doc)
+}
+
+// WriteCSV writes the tables one after another. When there is more than
+// one, each is preceded by a row holding its title and followed by an empty
+// row. Charts are left out; their data is expected in the tables. Cells are
+// escaped so spreadsheet apps do not evaluate them as formulas.
+func WriteCSV(w io.Writer, doc Document) error {
+	cw := csv.NewWriter(w)
+	write := func(row []string) error {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = csvCell(cell)
+		}
+		return cw.Write(escaped)
+	}
+
+	for i, t := range doc.Tables {
+		if len(doc.Tables) > 1 {
+			if i > 0 {
+				if err := write([]string{""}); err != nil {
+					return err
+				}
+			}
+			if err := write([]string{t.Title}); err != nil {
+				return err
+			}
+		}
+		if err := write(t.Columns); err != nil {
+			return err
+		}
+		for _, row := range t.Rows {
+			if err := write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell prefixes text that a spreadsheet would read as a formula with a
+// quote. Plain numbers, negative ones included, are left as they are.
+func csvCell(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
+
+// Number formats v with up to two decimals.
+func Number(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// Optional formats v, or returns an empty cell for nil.
+func Optional(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return Number(*v)
+}
+
+func Date(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// Timestamp formats t in loc to the minute.
+func Timestamp(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02 15:04")
+}
+
+const (
+	margin    = 40.0
+	rowHeight = 15.0
+	fontSize  = 9.0
+	chartSize = 170.0
+	cellInset = 4.0
+)
+
+var palette = [][3]uint8{{37, 99, 235}, {220, 38, 38}, {22, 163, 74}, {217, 119, 6}, {124, 58, 237}}
+
+type layout struct {
+	doc *pdf.Document
+	y   float64
+}
+
+func (l *layout) newPage() {
+	l.doc.AddPage()
+	l.y = margin
+}
+
+// ensure starts a new page unless h points still fit.
+func (l *layout) ensure(h float64) {
+	if l.doc.PageCount() == 0 || l.y+h > pdf.PageHeight-margin {
+		l.newPage()
+	}
+}
+
+func WritePDF(w io.Writer, doc Document) error {
+	l := &layout{doc: pdf.New()}
+	l.newPage()
+
+	l.doc.SetColor(17, 24, 39)
+	l.doc.Text(margin, l.y+16, pdf.Bold, 16, doc.Title)
+	l.y += 24
+	if doc.Subtitle != "" {
+		l.doc.SetColor(107, 114, 128)
+		l.doc.Text(margin, l.y+10, pdf.Regular, 10, doc.Subtitle)
+		l.y += 16
+	}
+	l.y += 10
+
+	for _, c := range doc.Charts {
+		l.chart(c)
+	}
+	for _, t := range doc.Tables {
+		l.table(t)
+	}
+
+	_, err := l.doc.WriteTo(w)
+	return err
+}
+
+func (l *layout) heading(title string) {
+	if title == "" {
+		return
+	}
+	l.doc.SetColor(17, 24, 39)
+	l.doc.Text(margin, l.y+12, pdf.Bold, 12, title)
+	l.y += 20
+}
+
+func (l *layout) chart(c Chart) {
+	l.ensure(20 + chartSize + 40)
+	l.heading(c.Title)
+
+	left, top := margin+40, l.y
+	width, height := pdf.PageWidth-margin-left, chartSize
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			if !math.IsNaN(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if math.IsInf(lo, 1) {
+		lo, hi = 0, 1
+	}
+	if lo > 0 {
+		lo = 0
+	}
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	// Axes with four horizontal grid lines.
+	l.doc.SetColor(209, 213, 219)
+	for i := 0; i <= 4; i++ {
+		y := top + height - height*float64(i)/4
+		l.doc.Line(left, y, left+width, y, 0.5)
+		l.doc.SetColor(107, 114, 128)
+		label := Number(lo + (hi-lo)*float64(i)/4)
+		l.doc.Text(left-cellInset-pdf.TextWidth(pdf.Regular, 7, label), y+2.5, pdf.Regular, 7, label)
+		l.doc.SetColor(209, 213, 219)
+	}
+
+	n := len(c.Labels)
+	xAt := func(i int) float64 {
+		if n <= 1 {
+			return left + width/2
+		}
+		return left + width*float64(i)/float64(n-1)
+	}
+	yAt := func(v float64) float64 {
+		return top + height - height*(v-lo)/(hi-lo)
+	}
+
+	// Label at most about eight points along the x axis.
+	step := 1
+	if n > 8 {
+		step = (n + 7) / 8
+	}
+	l.doc.SetColor(107, 114, 128)
+	for i := 0; i < n; i += step {
+		lw := pdf.TextWidth(pdf.Regular, 7, c.Labels[i])
+		l.doc.Text(xAt(i)-lw/2, top+height+10, pdf.Regular, 7, c.Labels[i])
+	}
+
+	legendX := left
+	for si, s := range c.Series {
+		col := palette[si%len(palette)]
+		l.doc.SetColor(col[0], col[1], col[2])
+
+		var points []float64
+		flush := func() {
+			if len(points) == 2 {
+				l.doc.Rect(points[0]-1.5, points[1]-1.5, 3, 3, true)
+			}
+			l.doc.Polyline(1.5, points...)
+			points = points[:0]
+		}
+		for i, v := range s.Values {
+			if i >= n {
+				break
+			}
+			if math.IsNaN(v) {
+				flush()
+				continue
+			}
+			points = append(points, xAt(i), yAt(v))
+		}
+		flush()
+
+		l.doc.Rect(legendX, top+height+18, 8, 8, true)
+		l.doc.SetColor(55, 65, 81)
+		l.doc.Text(legendX+11, top+height+25, pdf.Regular, 8, s.Name)
+		legendX += 11 + pdf.TextWidth(pdf.Regular, 8, s.Name) + 14
+	}
+
+	l.y = top + height + 44
+}
+
+func (l *layout) table(t Table) {
+	if len(t.Columns) == 0 {
+		return
+	}
+	l.ensure(20 + 2*rowHeight)
+	l.heading(t.Title)
+
+	widths := columnWidths(t, pdf.PageWidth-2*margin)
+	header := func() {
+		l.doc.SetColor(243, 244, 246)
+		l.doc.Rect(margin, l.y, pdf.PageWidth-2*margin, rowHeight, true)
+		l.doc.SetColor(17, 24, 39)
+		l.row(t.Columns, widths, pdf.Bold)
+	}
+	header()
+
+	for _, r := range t.Rows {
+		if l.y+rowHeight > pdf.PageHeight-margin {
+			l.newPage()
+			header()
+		}
+		l.doc.SetColor(55, 65, 81)
+		l.row(r, widths, pdf.Regular)
+		l.doc.SetColor(229, 231, 235)
+		l.doc.Line(margin, l.y, pdf.PageWidth-margin, l.y, 0.3)
+	}
+	l.y += 18
+}
+
+func (l *layout) row(cells []string, widths []float64, font pdf.Font) {
+	x := margin
+	for i, w := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = fit(cells[i], font, w-2*cellInset)
+		}
+		l.doc.Text(x+cellInset, l.y+rowHeight-4.5, font, fontSize, cell)
+		x += w
+	}
+	l.y += rowHeight
+}
+
+// columnWidths shares total between the columns in proportion to their
+// widest cell, giving every column at least a fair minimum.
+func columnWidths(t Table, total float64) []float64 {
+	want := make([]float64, len(t.Columns))
+	for i, c := range t.Columns {
+		want[i] = pdf.TextWidth(pdf.Bold, fontSize, c)
+	}
+	for _, r := range t.Rows {
+		for i := 0; i < len(r) && i < len(want); i++ {
+			want[i] = math.Max(want[i], pdf.TextWidth(pdf.Regular, fontSize, r[i]))
+		}
+	}
+
+	minimum := total / float64(len(want)) / 2
+	sum := 0.0
+	for i := range want {
+		want[i] = math.Max(want[i]+2*cellInset, minimum)
+		sum += want[i]
+	}
+	for i := range want {
+		want[i] *= total / sum
+	}
+	return want
+}
+
+// fit shortens s with an ellipsis until it is at most width wide.
+func fit(s string, font pdf.Font, width float64) string {
+	if pdf.TextWidth(font, fontSize, s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && pdf.TextWidth(font, fontSize, string(r)+"...") > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
+}
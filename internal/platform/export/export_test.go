@@ -0,0 +1,101 @@
+package export
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	doc := Document{
+		Title:  "Summary",
+		Charts: []Chart{{Title: "ignored"}},
+		Tables: []Table{
+			{Title: "Totals", Columns: []string{"sessions", "volume"}, Rows: [][]string{{"3", "1200.5"}}},
+			{Title: "Weeks", Columns: []string{"week", "notes"}, Rows: [][]string{{"2026-03-02", "heavy, felt good"}}},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := WriteCSV(&b, doc); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	want := "Totals\nsessions,volume\n3,1200.5\n\nWeeks\nweek,notes\n2026-03-02,\"heavy, felt good\"\n"
+	if b.String() != want {
+		t.Fatalf("expected %q, got %q", want, b.String())
+	}
+
+	b.Reset()
+	if err := WriteCSV(&b, Document{Tables: doc.Tables[:1]}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "sessions,volume\n") {
+		t.Fatalf("a single table should have no title row: %q", b.String())
+	}
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	doc := Document{Tables: []Table{{
+		Columns: []string{"plan", "change"},
+		Rows: [][]string{
+			{`=HYPERLINK("http://evil.example","x")`, "-12.5"},
+			{"+1+cmd", "-2+3"},
+			{"@SUM(A1)", "\tindent"},
+			{"Push Day", "5"},
+		},
+	}}}
+
+	var b bytes.Buffer
+	if err := WriteCSV(&b, doc); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	want := "plan,change\n" +
+		"\"'=HYPERLINK(\"\"http://evil.example\"\",\"\"x\"\")\",-12.5\n" +
+		"'+1+cmd,'-2+3\n" +
+		"'@SUM(A1),'\tindent\n" +
+		"Push Day,5\n"
+	if b.String() != want {
+		t.Fatalf("expected %q, got %q", want, b.String())
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	rows := make([][]string, 0, 120)
+	for i := 0; i < 120; i++ {
+		rows = append(rows, []string{strconv.Itoa(i), strings.Repeat("long text ", 30)})
+	}
+
+	doc := Document{
+		Title:    "Progress",
+		Subtitle: "Squat",
+		Charts: []Chart{{
+			Title:  "Estimated 1RM",
+			Labels: []string{"a", "b", "c"},
+			Series: []Series{{Name: "1RM", Values: []float64{100, math.NaN(), 110}}},
+		}},
+		Tables: []Table{{Title: "Sessions", Columns: []string{"#", "notes"}, Rows: rows}},
+	}
+
+	var b bytes.Buffer
+	if err := WritePDF(&b, doc); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.HasPrefix(b.String(), "%PDF-") {
+		t.Fatal("expected a PDF")
+	}
+	if !strings.Contains(b.String(), "/Count 3") {
+		t.Fatal("expected the table to continue over three pages")
+	}
+}
+
+func TestNumber(t *testing.T) {
+	for v, want := range map[float64]string{116.6666: "116.67", 100: "100", 0.5: "0.5"} {
+		if got := Number(v); got != want {
+			t.Fatalf("%v: expected %s, got %s", v, want, got)
+		}
+	}
+}
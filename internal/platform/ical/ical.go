@@ -0,0 +1,112 @@
+// Package ical renders RFC 5545 calendars with the handful of VEVENT
+// properties needed for workout feeds.
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxLineOctets = 75
+
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is a single VEVENT. For all-day events only the dates of Start and
+// End are used and End is exclusive.
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Description string
+	Status      string
+}
+
+func (c Calendar) Bytes() []byte {
+	var b bytes.Buffer
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:"+escapeText(c.ProdID))
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(&b, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(&b, "BEGIN:VEVENT")
+		writeLine(&b, "UID:"+escapeText(e.UID))
+		writeLine(&b, "DTSTAMP:"+formatUTC(e.Stamp))
+		if e.AllDay {
+			writeLine(&b, "DTSTART;VALUE=DATE:"+formatDate(e.Start))
+			writeLine(&b, "DTEND;VALUE=DATE:"+formatDate(e.End))
+		} else {
+			writeLine(&b, "DTSTART:"+formatUTC(e.Start))
+			writeLine(&b, "DTEND:"+formatUTC(e.End))
+		}
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if e.Status != "" {
+			writeLine(&b, "STATUS:"+e.Status)
+		}
+		writeLine(&b, "END:VEVENT")
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("20060102")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeLine writes a content line terminated by CRLF, folding it so no
+// physical line exceeds 75 octets and no UTF-8 sequence is split.
+func writeLine(b *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
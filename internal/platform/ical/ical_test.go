@@ -0,0 +1,92 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendar_Bytes(t *testing.T) {
+	stamp := time.Date(2026, 2, 15, 10, 0, 0, 0, time.UTC)
+	jakarta := time.FixedZone("WIB", 7*3600)
+
+	cal := Calendar{
+		ProdID: "-//Workout Tracker//EN",
+		Name:   "Workouts",
+		Events: []Event{
+			{
+				UID:         "s1@workout-tracker",
+				Stamp:       stamp,
+				Start:       time.Date(2026, 2, 20, 7, 30, 0, 0, jakarta),
+				End:         time.Date(2026, 2, 20, 8, 30, 0, 0, jakarta),
+				Summary:     "Push; Day, A",
+				Description: "Bench Press 3x10\nDips 3x12",
+				Status:      StatusConfirmed,
+			},
+			{
+				UID:     "s2@workout-tracker",
+				Stamp:   stamp,
+				Start:   time.Date(2026, 2, 21, 0, 0, 0, 0, time.UTC),
+				End:     time.Date(2026, 2, 22, 0, 0, 0, 0, time.UTC),
+				AllDay:  true,
+				Summary: "Legs",
+				Status:  StatusCancelled,
+			},
+		},
+	}
+
+	out := string(cal.Bytes())
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"X-WR-CALNAME:Workouts\r\n",
+		"DTSTART:20260220T003000Z\r\n",
+		"DTEND:20260220T013000Z\r\n",
+		`SUMMARY:Push\; Day\, A` + "\r\n",
+		`DESCRIPTION:Bench Press 3x10\nDips 3x12` + "\r\n",
+		"DTSTART;VALUE=DATE:20260221\r\n",
+		"DTEND;VALUE=DATE:20260222\r\n",
+		"STATUS:CANCELLED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("expected 2 events:\n%s", out)
+	}
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Fatal("bare LF found in output")
+	}
+}
+
+func TestWriteLine_Folds(t *testing.T) {
+	cal := Calendar{
+		ProdID: "x",
+		Events: []Event{{
+			UID:         "u",
+			Summary:     "s",
+			Description: strings.Repeat("é", 100),
+		}},
+	}
+
+	out := string(cal.Bytes())
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Fatalf("line exceeds %d octets: %q", maxLineOctets, line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+			continue
+		}
+		unfolded.WriteString("\n" + line)
+	}
+
+	if !strings.Contains(unfolded.String(), "DESCRIPTION:"+strings.Repeat("é", 100)) {
+		t.Fatal("folded description does not unfold to the original text")
+	}
+}
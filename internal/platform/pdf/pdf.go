@@ -0,0 +1,205 @@
+// Package pdf writes simple PDF 1.4 documents: text in the standard
+// Helvetica fonts, lines and rectangles. Nothing is embedded, so any viewer
+// can render the output.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+func (f Font) resource() string {
+	if f == Bold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Document collects pages. Coordinates are in points from the top-left
+// corner of the page; text is placed by its baseline.
+type Document struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+func (d *Document) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *Document) page() *bytes.Buffer {
+	if d.current == nil {
+		d.AddPage()
+	}
+	return d.current
+}
+
+// SetColor sets the stroke and fill color for what is drawn next.
+func (d *Document) SetColor(r, g, b uint8) {
+	fmt.Fprintf(d.page(), "%s %s %s RG %[1]s %[2]s %[3]s rg\n", unit(r), unit(g), unit(b))
+}
+
+func (d *Document) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font.resource(), num(size), num(x), num(PageHeight-y), encode(s))
+}
+
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.page(), "%s w %s %s m %s %s l S\n", num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Polyline strokes a path through the points, given as x, y pairs.
+func (d *Document) Polyline(width float64, points ...float64) {
+	if len(points) < 4 {
+		return
+	}
+	b := d.page()
+	fmt.Fprintf(b, "%s w %s %s m", num(width), num(points[0]), num(PageHeight-points[1]))
+	for i := 2; i+1 < len(points); i += 2 {
+		fmt.Fprintf(b, " %s %s l", num(points[i]), num(PageHeight-points[i+1]))
+	}
+	b.WriteString(" S\n")
+}
+
+// Rect draws the rectangle with its top-left corner at x, y, filled or
+// stroked.
+func (d *Document) Rect(x, y, w, h float64, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+	fmt.Fprintf(d.page(), "%s %s %s %s re %s\n", num(x), num(PageHeight-y-h), num(w), num(h), op)
+}
+
+// WriteTo renders the document. A document without pages gets one blank
+// page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page then
+	// takes a page object followed by its content stream.
+	kids := &bytes.Buffer{}
+	for i := range d.pages {
+		fmt.Fprintf(kids, "%d 0 R ", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, p := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), 6+2*i))
+
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write(p.Bytes()); err != nil {
+			return 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return 0, err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(out.Bytes())
+	return int64(n), err
+}
+
+// encode turns s into the body of a literal string in WinAnsiEncoding.
+// Characters outside Latin-1 become '?'.
+func encode(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func unit(c uint8) string {
+	return strconv.FormatFloat(float64(c)/255, 'f', 3, 64)
+}
+
+// helveticaWidths are the advance widths of ASCII 32-126 in Helvetica, per
+// 1000 units of font size.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// TextWidth estimates how wide s renders. Bold is approximated from the
+// regular metrics.
+func TextWidth(font Font, size float64, s string) float64 {
+	units := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			units += helveticaWidths[r-32]
+		} else {
+			units += 556
+		}
+	}
+	w := float64(units) * size / 1000
+	if font == Bold {
+		w *= 1.06
+	}
+	return w
+}
@@ -0,0 +1,69 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	d := New()
+	d.Text(40, 60, Bold, 16, "Report (draft)")
+	d.Line(40, 70, 200, 70, 0.5)
+	d.AddPage()
+	d.Rect(40, 40, 100, 20, true)
+
+	var b bytes.Buffer
+	if _, err := d.WriteTo(&b); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := b.String()
+
+	if !strings.HasPrefix(out, "%PDF-1.4\n") || !strings.HasSuffix(out, "%%EOF\n") {
+		t.Fatalf("unexpected envelope: %q", out[:20])
+	}
+	if !strings.Contains(out, "/Count 2") {
+		t.Fatal("expected two pages")
+	}
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(out)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(m[1])
+	if !strings.HasPrefix(out[xref:], "xref\n0 9\n") {
+		t.Fatalf("startxref does not point at the xref table: %q", out[xref:xref+10])
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(out[xref:], -1)
+	if len(entries) != 8 {
+		t.Fatalf("expected 8 objects, got %d", len(entries))
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(out[off:], want) {
+			t.Fatalf("object %d offset points at %q", i+1, out[off:off+10])
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	if got := encode(`a(b)\c`); got != `a\(b\)\\c` {
+		t.Fatalf("escape: got %q", got)
+	}
+	if got := encode("3×10 é ✓"); got != `3\32710 \351 ?` {
+		t.Fatalf("latin-1: got %q", got)
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	if got := TextWidth(Regular, 10, "Hi"); got != 9.44 {
+		t.Fatalf("expected 9.44, got %v", got)
+	}
+	if TextWidth(Bold, 10, "Hi") <= TextWidth(Regular, 10, "Hi") {
+		t.Fatal("expected bold to be wider")
+	}
+}
@@ -0,0 +1,304 @@
+// Package rrule implements the subset of RFC 5545 recurrence rules used for
+// scheduling: FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY, COUNT and UNTIL.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxPeriods bounds expansion of rules that would otherwise never reach the
+// requested window.
+const maxPeriods = 100000
+
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	// Until is inclusive. When UntilDate is set only its calendar day is
+	// significant and it is compared against the occurrence's local date.
+	Until     time.Time
+	UntilDate bool
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+func Parse(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "rrule:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := Rule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return Rule{}, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case Daily, Weekly, Monthly:
+				r.Freq = Frequency(value)
+			default:
+				return Rule{}, fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRule)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRule)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, isDate, err := parseUntil(value)
+			if err != nil {
+				return Rule{}, err
+			}
+			r.Until = t
+			r.UntilDate = isDate
+		case "BYDAY":
+			days, err := parseByDay(value)
+			if err != nil {
+				return Rule{}, err
+			}
+			r.ByDay = days
+		case "WKST":
+			if value != "MO" {
+				return Rule{}, fmt.Errorf("%w: only WKST=MO is supported", ErrInvalidRule)
+			}
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+		}
+	}
+
+	if r.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRule)
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	seen := map[time.Weekday]bool{}
+	var days []time.Weekday
+	for _, code := range strings.Split(value, ",") {
+		wd, ok := weekdayCodes[strings.TrimSpace(code)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRule, code)
+		}
+		if !seen[wd] {
+			seen[wd] = true
+			days = append(days, wd)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return mondayOffset(days[i]) < mondayOffset(days[j]) })
+	return days, nil
+}
+
+// String returns the rule in canonical RRULE form, without the "RRULE:" prefix.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			codes = append(codes, strings.ToUpper(wd.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns the occurrences of the rule anchored at start that fall in
+// [from, to). Occurrences keep start's wall-clock time in start's location.
+// COUNT is always counted from start, regardless of from.
+func (r Rule) Between(start, from, to time.Time) []time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	var out []time.Time
+	counted := 0
+	for p := 0; p < maxPeriods; p++ {
+		begin, candidates := r.period(start, p*interval)
+		if !begin.Before(to) {
+			return out
+		}
+		if r.pastUntil(begin) {
+			return out
+		}
+
+		for _, c := range candidates {
+			if c.Before(start) {
+				continue
+			}
+			if r.pastUntil(c) {
+				return out
+			}
+			counted++
+			if r.Count > 0 && counted > r.Count {
+				return out
+			}
+			if !c.Before(to) {
+				return out
+			}
+			if !c.Before(from) {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// First returns the first occurrence of the rule anchored at start.
+func (r Rule) First(start time.Time) (time.Time, bool) {
+	// A generous window keeps sparse rules (e.g. every 12 months on the 31st)
+	// from being reported as empty.
+	occ := r.Between(start, start, start.AddDate(20, 0, 0))
+	if len(occ) == 0 {
+		return time.Time{}, false
+	}
+	return occ[0], true
+}
+
+// period returns the first instant of the n-th period after start together
+// with the candidate occurrences inside it, in ascending order.
+func (r Rule) period(start time.Time, n int) (time.Time, []time.Time) {
+	loc := start.Location()
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hh, mm, ss, start.Nanosecond(), loc)
+	}
+
+	switch r.Freq {
+	case Weekly:
+		weekStart := d - mondayOffset(start.Weekday()) + 7*n
+		begin := time.Date(y, m, weekStart, 0, 0, 0, 0, loc)
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		out := make([]time.Time, 0, len(days))
+		for _, wd := range days {
+			out = append(out, at(y, m, weekStart+mondayOffset(wd)))
+		}
+		return begin, out
+
+	case Monthly:
+		begin := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, loc)
+		by, bm, _ := begin.Date()
+		daysInMonth := time.Date(by, bm+1, 0, 0, 0, 0, 0, loc).Day()
+		if len(r.ByDay) == 0 {
+			if d > daysInMonth {
+				return begin, nil
+			}
+			return begin, []time.Time{at(by, bm, d)}
+		}
+		var out []time.Time
+		for day := 1; day <= daysInMonth; day++ {
+			c := at(by, bm, day)
+			if r.hasDay(c.Weekday()) {
+				out = append(out, c)
+			}
+		}
+		return begin, out
+
+	default:
+		c := at(y, m, d+n)
+		begin := time.Date(y, m, d+n, 0, 0, 0, 0, loc)
+		if len(r.ByDay) > 0 && !r.hasDay(c.Weekday()) {
+			return begin, nil
+		}
+		return begin, []time.Time{c}
+	}
+}
+
+func (r Rule) hasDay(wd time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) pastUntil(t time.Time) bool {
+	if r.Until.IsZero() {
+		return false
+	}
+	if r.UntilDate {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(r.Until)
+	}
+	return t.After(r.Until)
+}
+
+func mondayOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
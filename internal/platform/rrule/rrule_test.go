@@ -0,0 +1,201 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func dates(ts []time.Time) []string {
+	out := make([]string, 0, len(ts))
+	for _, t := range ts {
+		out = append(out, t.Format("2006-01-02 15:04"))
+	}
+	return out
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"RRULE:freq=weekly;byday=fr,mo,we;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR"},
+		{"FREQ=MONTHLY;COUNT=3", "FREQ=MONTHLY;COUNT=3"},
+		{"FREQ=WEEKLY;UNTIL=20260301", "FREQ=WEEKLY;UNTIL=20260301"},
+		{"FREQ=DAILY;UNTIL=20260301T120000Z;WKST=MO", "FREQ=DAILY;UNTIL=20260301T120000Z"},
+	}
+
+	for _, tt := range tests {
+		r, err := Parse(tt.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.in, err)
+		}
+		if got := r.String(); got != tt.want {
+			t.Fatalf("Parse(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260301",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYHOUR=7",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ",
+	} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalidRule) {
+			t.Fatalf("Parse(%q) error = %v, want ErrInvalidRule", in, err)
+		}
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wednesday 2026-03-04 07:30 in Jakarta.
+	start := time.Date(2026, 3, 4, 7, 30, 0, 0, jakarta)
+	far := start.AddDate(1, 0, 0)
+
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		to   time.Time
+		want []string
+	}{
+		{
+			name: "daily count",
+			rule: "FREQ=DAILY;COUNT=3",
+			from: start,
+			to:   far,
+			want: []string{"2026-03-04 07:30", "2026-03-05 07:30", "2026-03-06 07:30"},
+		},
+		{
+			name: "weekly byday skips days before start",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			from: start,
+			to:   far,
+			want: []string{"2026-03-04 07:30", "2026-03-06 07:30", "2026-03-09 07:30", "2026-03-11 07:30"},
+		},
+		{
+			name: "biweekly defaults to start weekday",
+			rule: "FREQ=WEEKLY;INTERVAL=2;UNTIL=20260401",
+			from: start,
+			to:   far,
+			want: []string{"2026-03-04 07:30", "2026-03-18 07:30", "2026-04-01 07:30"},
+		},
+		{
+			name: "window does not reset count",
+			rule: "FREQ=DAILY;COUNT=5",
+			from: time.Date(2026, 3, 7, 0, 0, 0, 0, jakarta),
+			to:   far,
+			want: []string{"2026-03-07 07:30", "2026-03-08 07:30"},
+		},
+		{
+			name: "unbounded rule stops at window end",
+			rule: "FREQ=DAILY;INTERVAL=3",
+			from: start,
+			to:   time.Date(2026, 3, 12, 0, 0, 0, 0, jakarta),
+			want: []string{"2026-03-04 07:30", "2026-03-07 07:30", "2026-03-10 07:30"},
+		},
+		{
+			name: "daily byday filter",
+			rule: "FREQ=DAILY;BYDAY=SA,SU",
+			from: start,
+			to:   time.Date(2026, 3, 15, 0, 0, 0, 0, jakarta),
+			want: []string{"2026-03-07 07:30", "2026-03-08 07:30", "2026-03-14 07:30"},
+		},
+		{
+			name: "monthly byday",
+			rule: "FREQ=MONTHLY;BYDAY=WE;COUNT=6",
+			from: start,
+			to:   far,
+			want: []string{"2026-03-04 07:30", "2026-03-11 07:30", "2026-03-18 07:30", "2026-03-25 07:30", "2026-04-01 07:30", "2026-04-08 07:30"},
+		},
+		{
+			name: "until timestamp is inclusive",
+			rule: "FREQ=DAILY;UNTIL=20260305T003000Z",
+			from: start,
+			to:   far,
+			want: []string{"2026-03-04 07:30", "2026-03-05 07:30"},
+		},
+	}
+
+	for _, tt := range tests {
+		r, err := Parse(tt.rule)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := dates(r.Between(start, tt.from, tt.to))
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("%s: got %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestRule_MonthlySkipsShortMonths(t *testing.T) {
+	start := time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC)
+	r, err := Parse("FREQ=MONTHLY;COUNT=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := dates(r.Between(start, start, start.AddDate(1, 0, 0)))
+	want := []string{"2026-01-31 18:00", "2026-03-31 18:00", "2026-05-31 18:00"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRule_KeepsWallClockAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 3, 6, 7, 0, 0, 0, ny)
+	r, err := Parse("FREQ=DAILY;COUNT=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, occ := range r.Between(start, start, start.AddDate(0, 1, 0)) {
+		if occ.Hour() != 7 {
+			t.Fatalf("expected 07:00 local, got %s", occ)
+		}
+	}
+}
+
+func TestRule_First(t *testing.T) {
+	start := time.Date(2026, 3, 4, 7, 30, 0, 0, time.UTC)
+
+	r, _ := Parse("FREQ=WEEKLY;BYDAY=SA")
+	first, ok := r.First(start)
+	if !ok || first.Format("2006-01-02") != "2026-03-07" {
+		t.Fatalf("First() = %s, %v", first, ok)
+	}
+
+	r, _ = Parse("FREQ=DAILY;UNTIL=20260301")
+	if _, ok := r.First(start); ok {
+		t.Fatal("expected no occurrence when UNTIL precedes start")
+	}
+}
@@ -0,0 +1,28 @@
+// Package worker runs background jobs inside the API process.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Every runs fn right away and then once per interval until ctx is done.
+// Errors are logged and do not stop the loop. It blocks, so callers usually
+// start it in a goroutine.
+func Every(ctx context.Context, logger *slog.Logger, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && ctx.Err() == nil {
+			logger.Error("background_job_failed", "job", name, "error", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
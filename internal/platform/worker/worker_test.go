@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEvery_RunsUntilCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	var runs atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		Every(ctx, logger, "test", time.Millisecond, func(context.Context) error {
+			if runs.Add(1) == 3 {
+				cancel()
+			}
+			return errors.New("keeps going")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Every did not return after cancel")
+	}
+	if got := runs.Load(); got != 3 {
+		t.Fatalf("expected 3 runs, got %d", got)
+	}
+}
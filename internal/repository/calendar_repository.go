@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type CalendarRepository interface {
+	// SaveFeedToken stores the hash of the user's feed token, replacing any
+	// previous one.
+	SaveFeedToken(ctx context.Context, userID string, tokenHash string) error
+	GetFeedUserID(ctx context.Context, tokenHash string) (string, error)
+	DeleteFeedToken(ctx context.Context, userID string) error
+	GetEntries(ctx context.Context, userID string, from time.Time) ([]domain.CalendarEntry, error)
+	// GetSchedulesBetween lists schedules whose date is within [from, to],
+	// joined with plan names.
+	GetSchedulesBetween(ctx context.Context, userID string, from, to time.Time) ([]domain.AgendaItem, error)
+	// GetCompletedSessions lists sessions finished within [start, end).
+	GetCompletedSessions(ctx context.Context, userID string, start, end time.Time) ([]domain.WorkoutSession, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type DigestRepository interface {
+	// GetDueRecipients lists users who receive the weekly digest and have
+	// none queued yet for the last full week before now in their timezone,
+	// ordered by user id and starting after the user id after when it is set.
+	GetDueRecipients(ctx context.Context, now time.Time, after string, limit int) ([]domain.DigestRecipient, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type MailOutboxRepository interface {
+	// Enqueue queues a message. It reports false when a message of the same
+	// kind and period is already queued for the user.
+	Enqueue(ctx context.Context, m *domain.MailMessage) (bool, error)
+	// ClaimDue marks up to limit messages due at now as sending until
+	// claimedUntil and returns them. Concurrent callers get distinct
+	// messages.
+	ClaimDue(ctx context.Context, now, claimedUntil time.Time, limit int) ([]domain.MailMessage, error)
+	MarkSent(ctx context.Context, id string, sentAt time.Time) error
+	// MarkFailed records a failed delivery. The message is retried at
+	// retryAt, or given up on when retryAt is nil.
+	MarkFailed(ctx context.Context, id, reason string, retryAt *time.Time) error
+}
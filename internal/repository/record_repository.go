@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type RecordRepository interface {
+	// GetCurrent returns the user's standing record for every exercise and
+	// type, optionally limited to the given exercises.
+	GetCurrent(ctx context.Context, userID string, exerciseIDs []string) ([]domain.PersonalRecord, error)
+	// GetHistory returns every record the user has set, newest first.
+	GetHistory(ctx context.Context, userID, exerciseID string) ([]domain.PersonalRecord, error)
+	// GetAchieved returns the records the user set within [start, end),
+	// ordered by exercise name.
+	GetAchieved(ctx context.Context, userID string, start, end time.Time) ([]domain.PersonalRecord, error)
+}
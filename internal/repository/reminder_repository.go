@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type ReminderRepository interface {
+	// GetDue lists pending schedules whose reminder time has passed but
+	// that have not started yet and were not delivered on channel. Failed
+	// deliveries are listed again once their retry time has passed, after
+	// the reminders that were never tried.
+	GetDue(ctx context.Context, channel string, now time.Time, limit int) ([]domain.Reminder, error)
+	// Claim records a delivery before it is sent. It reports false when the
+	// reminder was already claimed, e.g. by another instance.
+	Claim(ctx context.Context, scheduleID, channel string, scheduledAt, now time.Time) (bool, error)
+	// MarkFailed records a failed delivery. It is retried at retryAt, or
+	// never when retryAt is nil.
+	MarkFailed(ctx context.Context, scheduleID, channel string, scheduledAt time.Time, reason string, retryAt *time.Time) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+// ReportRepository reads report inputs. The daily methods use the user's
+// rollups when they were built for timezone and fall back to aggregating the
+// sessions otherwise.
+type ReportRepository interface {
+	// GetDailyStats totals the user's finished sessions per local date in
+	// timezone, for the calendar days first through last, oldest first.
+	GetDailyStats(ctx context.Context, userID, timezone string, first, last time.Time) ([]domain.DailyStats, error)
+	// GetPerformedSets lists the working sets of an exercise in the user's
+	// finished sessions, optionally limited to sessions started within
+	// [start, end), oldest first.
+	GetPerformedSets(ctx context.Context, userID, exerciseID string, start, end *time.Time) ([]domain.PerformedSet, error)
+	// GetMuscleWork returns the daily load of every exercise in the user's
+	// finished sessions, per local date in timezone, for the calendar days
+	// first through last.
+	GetMuscleWork(ctx context.Context, userID, timezone string, first, last time.Time) ([]domain.MuscleWork, error)
+	// GetScheduleStatuses returns the date and status of the user's
+	// scheduled workouts on the calendar days first through last.
+	GetScheduleStatuses(ctx context.Context, userID string, first, last time.Time) ([]domain.ScheduledWorkout, error)
+	// GetTrainingDays returns the distinct local dates, in timezone, on which
+	// the user started a finished session, oldest first.
+	GetTrainingDays(ctx context.Context, userID, timezone string) ([]time.Time, error)
+	// GetSessionLog lists every set of the user's finished sessions,
+	// optionally limited to sessions started within [start, end), oldest
+	// first.
+	GetSessionLog(ctx context.Context, userID string, start, end *time.Time) ([]domain.SessionLogEntry, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import "context"
+
+// RollupRepository maintains the per-user daily report aggregates, keyed by
+// local date in the user's timezone.
+type RollupRepository interface {
+	// RefreshSession recomputes the rollups of the day the user's session
+	// started on. When the user has no rollups yet, or they were built for
+	// another timezone, it rebuilds all of them instead.
+	RefreshSession(ctx context.Context, userID, sessionID string) error
+	// Invalidate drops the user's rollup state so reports read the sessions
+	// until the rollups are rebuilt.
+	Invalidate(ctx context.Context, userID string) error
+	// RebuildUser recomputes all of the user's rollups.
+	RebuildUser(ctx context.Context, userID string) error
+	// RebuildAll recomputes the rollups of every user and returns how many
+	// users were rebuilt.
+	RebuildAll(ctx context.Context) (int, error)
+}
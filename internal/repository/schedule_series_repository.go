@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type ScheduleSeriesRepository interface {
+	Create(ctx context.Context, s *domain.ScheduleSeries) error
+	GetByID(ctx context.Context, id string, userID string) (*domain.ScheduleSeries, error)
+	GetByUser(ctx context.Context, userID string) ([]domain.ScheduleSeries, error)
+	// GetStaleUserIDs returns up to limit users owning a series whose
+	// occurrences are stored only up to a date before before, or not at all.
+	GetStaleUserIDs(ctx context.Context, before time.Time, limit int) ([]string, error)
+	// Update saves the series and discards its pending, non-detached
+	// occurrences dated on or after from so they can be regenerated.
+	Update(ctx context.Context, s *domain.ScheduleSeries, from time.Time) error
+	// Delete removes the series together with its pending occurrences dated
+	// on or after from. Earlier occurrences are kept as standalone schedules.
+	Delete(ctx context.Context, id string, userID string, from time.Time) error
+	// AddOccurrences inserts occurrences, skipping any that collide with an
+	// existing schedule, and records through as the materialized horizon.
+	AddOccurrences(ctx context.Context, seriesID string, occurrences []domain.ScheduledWorkout, through time.Time) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"workout-tracker/internal/domain"
 )
@@ -9,6 +10,19 @@ import (
 type ScheduledWorkoutRepository interface {
 	Create(ctx context.Context, sw *domain.ScheduledWorkout) error
 	GetByUser(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.ScheduledWorkout], error)
+	// GetAgenda lists schedules like GetByUser, joined with plan names.
+	GetAgenda(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.AgendaItem], error)
+	GetByID(ctx context.Context, id string, userID string) (*domain.ScheduledWorkout, error)
+	GetOccurrence(ctx context.Context, seriesID string, userID string, date time.Time) (*domain.ScheduledWorkout, error)
+	// Update saves the plan and timing of a schedule. It returns
+	// sql.ErrNoRows when the row is missing or the change would duplicate
+	// another schedule of the same plan on the same day.
+	Update(ctx context.Context, sw *domain.ScheduledWorkout) error
+	UpdateStatus(ctx context.Context, sw *domain.ScheduledWorkout) error
+	// MarkMissed moves pending schedules that ended more than grace before
+	// now to missed and returns how many changed. It does nothing when
+	// another instance is already running it.
+	MarkMissed(ctx context.Context, now time.Time, grace time.Duration) (int, error)
 	Delete(ctx context.Context, id string, userID string) error
 }
 
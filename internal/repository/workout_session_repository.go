@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"workout-tracker/internal/domain"
+)
+
+type WorkoutSessionRepository interface {
+	Create(ctx context.Context, s *domain.WorkoutSession) error
+	GetByID(ctx context.Context, id string, userID string) (*domain.WorkoutSession, error)
+	GetByUser(ctx context.Context, userID string, pagination domain.Pagination, filters domain.WorkoutSessionFilter) (domain.PaginatedResult[domain.WorkoutSession], error)
+	AddExercise(ctx context.Context, ex *domain.WorkoutSessionExercise) error
+	UpdateExerciseActuals(ctx context.Context, sessionID string, ex *domain.WorkoutSessionExercise) error
+	AddSet(ctx context.Context, set *domain.WorkoutSessionSet) error
+	UpdateSet(ctx context.Context, set *domain.WorkoutSessionSet) error
+	// Finish completes the session and stores the personal records it set
+	// in the same transaction.
+	Finish(ctx context.Context, id string, userID string, completedAt time.Time, notes string, records []domain.PersonalRecord) error
+}
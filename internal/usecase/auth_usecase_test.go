@@ -121,6 +121,45 @@ func TestUserUsecase_GetByID(t *testing.T) {
 	})
 }
 
+func TestUserUsecase_UpdatePreferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		repo := new(mocks.MockUserRepository)
+		uc := usecase.NewUserUsecase(repo, auth.NewJWTService("secret"))
+		tz := "Nowhere/Special"
+		_, err := uc.UpdatePreferences(context.Background(), "u1", domain.UserPreferencesUpdate{Timezone: &tz})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reminder lead out of range", func(t *testing.T) {
+		repo := new(mocks.MockUserRepository)
+		uc := usecase.NewUserUsecase(repo, auth.NewJWTService("secret"))
+		lead := domain.MaxReminderLeadMinutes + 1
+		_, err := uc.UpdatePreferences(context.Background(), "u1", domain.UserPreferencesUpdate{ReminderLeadMinutes: &lead})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.MockUserRepository)
+		tz := " Asia/Jakarta "
+		repo.On("UpdatePreferences", mock.Anything, "u1", mock.MatchedBy(func(p domain.UserPreferencesUpdate) bool {
+			return p.Timezone != nil && *p.Timezone == "Asia/Jakarta"
+		})).Return(nil).Once()
+		repo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "Asia/Jakarta"}, nil).Once()
+
+		uc := usecase.NewUserUsecase(repo, auth.NewJWTService("secret"))
+		u, err := uc.UpdatePreferences(context.Background(), "u1", domain.UserPreferencesUpdate{Timezone: &tz})
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Jakarta", u.Timezone)
+		repo.AssertExpectations(t)
+	})
+}
+
 func TestUserUsecase_Login(t *testing.T) {
 	t.Parallel()
 
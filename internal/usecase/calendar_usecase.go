@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/platform/ical"
+	"workout-tracker/internal/repository"
+)
+
+const (
+	feedTokenBytes = 32
+	// feedPastDays is how far back the feed reaches, so recently finished
+	// workouts stay visible in calendar clients.
+	feedPastDays = 30
+	// feedEventDuration is the length given to timed events, as schedules
+	// only record a start time.
+	feedEventDuration = time.Hour
+	// maxCalendarViewDays bounds the requested range of a calendar view.
+	maxCalendarViewDays = 366
+)
+
+type CalendarUsecase struct {
+	repo      repository.CalendarRepository
+	schedules *ScheduledWorkoutUsecase
+}
+
+func NewCalendarUsecase(repo repository.CalendarRepository, schedules *ScheduledWorkoutUsecase) *CalendarUsecase {
+	return &CalendarUsecase{repo: repo, schedules: schedules}
+}
+
+// CreateFeed issues a new secret feed token for the user, revoking any
+// previous one. Only a hash of the token is stored.
+func (u *CalendarUsecase) CreateFeed(ctx context.Context, userID string) (string, error) {
+	if userID == "" {
+		return "", fmt.Errorf("create feed: %w", domain.ErrInvalidInput)
+	}
+
+	raw := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("create feed: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := u.repo.SaveFeedToken(ctx, userID, hashFeedToken(token)); err != nil {
+		return "", fmt.Errorf("create feed: %w", err)
+	}
+
+	return token, nil
+}
+
+func (u *CalendarUsecase) RevokeFeed(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("revoke feed: %w", domain.ErrInvalidInput)
+	}
+
+	if err := u.repo.DeleteFeedToken(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("revoke feed: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("revoke feed: %w", err)
+	}
+
+	return nil
+}
+
+// FeedICS renders the calendar behind a feed token. Unknown or revoked
+// tokens are reported as not found.
+func (u *CalendarUsecase) FeedICS(ctx context.Context, token string) ([]byte, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("feed ics: %w", domain.ErrNotFound)
+	}
+
+	userID, err := u.repo.GetFeedUserID(ctx, hashFeedToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("feed ics: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("feed ics: %w", err)
+	}
+
+	if err := u.schedules.MaterializeSeries(ctx, userID, nil); err != nil {
+		return nil, fmt.Errorf("feed ics: %w", err)
+	}
+
+	now := time.Now().UTC()
+	entries, err := u.repo.GetEntries(ctx, userID, domain.CivilDate(now).AddDate(0, 0, -feedPastDays))
+	if err != nil {
+		return nil, fmt.Errorf("feed ics: %w", err)
+	}
+
+	cal := ical.Calendar{
+		ProdID: "-//Workout Tracker//Schedule//EN",
+		Name:   "Workouts",
+		Events: make([]ical.Event, 0, len(entries)),
+	}
+	for _, e := range entries {
+		cal.Events = append(cal.Events, calendarEvent(e, now))
+	}
+
+	return cal.Bytes(), nil
+}
+
+// GetCalendarView buckets the user's schedules and finished sessions by day,
+// week or month in the user's timezone. from and to default to the current
+// month; the range is widened to whole periods.
+func (u *CalendarUsecase) GetCalendarView(ctx context.Context, userID string, from, to *time.Time, granularity string) (*domain.CalendarView, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("get calendar view: %w", domain.ErrInvalidInput)
+	}
+
+	granularity = strings.ToLower(strings.TrimSpace(granularity))
+	if granularity == "" {
+		granularity = domain.CalendarDay
+	}
+	if !domain.IsValidCalendarGranularity(granularity) {
+		return nil, fmt.Errorf("get calendar view: %w", domain.ErrInvalidInput)
+	}
+	if from != nil && to != nil && !validCalendarRange(domain.CivilDate(*from), domain.CivilDate(*to)) {
+		return nil, fmt.Errorf("get calendar view: %w", domain.ErrInvalidInput)
+	}
+
+	loc, err := u.schedules.resolveTimezone(ctx, userID, "")
+	if err != nil {
+		return nil, fmt.Errorf("get calendar view: %w", err)
+	}
+
+	var first, last time.Time
+	if from != nil {
+		first = domain.CivilDate(*from)
+	} else {
+		first = domain.CalendarPeriodStart(time.Now().In(loc), domain.CalendarMonth)
+	}
+	if to != nil {
+		last = domain.CivilDate(*to)
+	} else {
+		last = first.AddDate(0, 1, -1)
+	}
+	if !validCalendarRange(first, last) {
+		return nil, fmt.Errorf("get calendar view: %w", domain.ErrInvalidInput)
+	}
+
+	first = domain.CalendarPeriodStart(first, granularity)
+	last = domain.CalendarPeriodNext(domain.CalendarPeriodStart(last, granularity), granularity).AddDate(0, 0, -1)
+
+	// Occurrences are never stored past the maximum horizon, so views
+	// further ahead only show what was scheduled there directly.
+	through := last
+	if limit := maxSeriesHorizon(loc); through.After(limit) {
+		through = limit
+	}
+	if err := u.schedules.MaterializeSeries(ctx, userID, &through); err != nil {
+		return nil, fmt.Errorf("get calendar view: %w", err)
+	}
+
+	// Timed schedules are stored with the date of their own timezone, which
+	// can be a day off from the user's.
+	schedules, err := u.repo.GetSchedulesBetween(ctx, userID, first.AddDate(0, 0, -1), last.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("get calendar view: %w", err)
+	}
+
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc)
+	sessions, err := u.repo.GetCompletedSessions(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("get calendar view: %w", err)
+	}
+
+	view := &domain.CalendarView{Timezone: loc.String(), Granularity: granularity, From: first, To: last}
+	index := map[time.Time]int{}
+	for p := first; !p.After(last); p = domain.CalendarPeriodNext(p, granularity) {
+		index[p] = len(view.Buckets)
+		view.Buckets = append(view.Buckets, domain.CalendarBucket{
+			Start:        p,
+			End:          domain.CalendarPeriodNext(p, granularity).AddDate(0, 0, -1),
+			Schedules:    []domain.AgendaItem{},
+			Sessions:     []domain.WorkoutSession{},
+			StatusCounts: map[string]int{},
+		})
+	}
+	bucketFor := func(day time.Time) *domain.CalendarBucket {
+		if day.Before(first) || day.After(last) {
+			return nil
+		}
+		return &view.Buckets[index[domain.CalendarPeriodStart(day, granularity)]]
+	}
+
+	for _, item := range schedules {
+		day := item.ScheduledDate
+		if !item.AllDay {
+			day = domain.CivilDate(item.ScheduledAt.In(loc))
+		}
+		if b := bucketFor(day); b != nil {
+			b.Schedules = append(b.Schedules, item)
+			b.StatusCounts[item.Status]++
+		}
+	}
+	for _, s := range sessions {
+		if s.CompletedAt == nil {
+			continue
+		}
+		if b := bucketFor(domain.CivilDate(s.CompletedAt.In(loc))); b != nil {
+			b.Sessions = append(b.Sessions, s)
+		}
+	}
+
+	return view, nil
+}
+
+func validCalendarRange(first, last time.Time) bool {
+	return !last.Before(first) && last.Sub(first) <= maxCalendarViewDays*24*time.Hour
+}
+
+func calendarEvent(e domain.CalendarEntry, stamp time.Time) ical.Event {
+	sw := e.Schedule
+	ev := ical.Event{
+		UID:         sw.ID + "@workout-tracker",
+		Stamp:       stamp,
+		Start:       sw.ScheduledAt,
+		End:         sw.ScheduledAt.Add(feedEventDuration),
+		AllDay:      sw.AllDay,
+		Summary:     e.PlanName,
+		Description: exerciseSummary(e.Exercises),
+		Status:      ical.StatusConfirmed,
+	}
+	if sw.AllDay {
+		ev.Start = sw.ScheduledDate
+		ev.End = sw.ScheduledDate.AddDate(0, 0, 1)
+	}
+	if sw.Status == domain.ScheduleStatusCanceled {
+		ev.Status = ical.StatusCancelled
+	}
+	return ev
+}
+
+// exerciseSummary lists one exercise per line, e.g. "Bench Press 3x10 @ 60kg".
+func exerciseSummary(exercises []domain.CalendarExercise) string {
+	lines := make([]string, 0, len(exercises))
+	for _, ex := range exercises {
+		line := fmt.Sprintf("%s %dx%d", ex.Name, ex.Sets, ex.Reps)
+		if ex.Weight > 0 {
+			line += " @ " + strconv.FormatFloat(ex.Weight, 'f', -1, 64) + "kg"
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,269 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func newCalendarUsecase() (*usecase.CalendarUsecase, *mocks.MockCalendarRepository, *mocks.MockScheduleSeriesRepository) {
+	repo := new(mocks.MockCalendarRepository)
+	seriesRepo := new(mocks.MockScheduleSeriesRepository)
+	schedules := usecase.NewScheduledWorkoutUsecase(new(mocks.MockScheduledWorkoutRepository), new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), seriesRepo)
+	return usecase.NewCalendarUsecase(repo, schedules), repo, seriesRepo
+}
+
+func TestCalendarUsecase_CreateFeed(t *testing.T) {
+	t.Parallel()
+
+	uc, repo, _ := newCalendarUsecase()
+
+	var stored string
+	repo.On("SaveFeedToken", mock.Anything, "u1", mock.AnythingOfType("string")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.String(2)
+	}).Once()
+
+	token, err := uc.CreateFeed(context.Background(), "u1")
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	sum := sha256.Sum256([]byte(token))
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored)
+	assert.NotContains(t, stored, token)
+	repo.AssertExpectations(t)
+}
+
+func TestCalendarUsecase_RevokeFeed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "revoked"},
+		{name: "no feed", repoErr: sql.ErrNoRows, expectedErr: domain.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, repo, _ := newCalendarUsecase()
+			repo.On("DeleteFeedToken", mock.Anything, "u1").Return(tt.repoErr).Once()
+
+			err := uc.RevokeFeed(context.Background(), "u1")
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalendarUsecase_FeedICS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown token", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, _ := newCalendarUsecase()
+		repo.On("GetFeedUserID", mock.Anything, mock.AnythingOfType("string")).Return("", sql.ErrNoRows).Once()
+
+		_, err := uc.FeedICS(context.Background(), "nope")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("renders entries", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, seriesRepo := newCalendarUsecase()
+		sum := sha256.Sum256([]byte("secret"))
+		repo.On("GetFeedUserID", mock.Anything, hex.EncodeToString(sum[:])).Return("u1", nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+
+		day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+		repo.On("GetEntries", mock.Anything, "u1", mock.AnythingOfType("time.Time")).Return([]domain.CalendarEntry{
+			{
+				Schedule: domain.ScheduledWorkout{
+					ID: "s1", ScheduledDate: day, ScheduledAt: day.Add(6 * time.Hour), Timezone: "UTC",
+					Status: domain.ScheduleStatusPending,
+				},
+				PlanName: "Push Day",
+				Exercises: []domain.CalendarExercise{
+					{Name: "Bench Press", Sets: 3, Reps: 10, Weight: 60},
+					{Name: "Push Up", Sets: 3, Reps: 15},
+				},
+			},
+			{
+				Schedule: domain.ScheduledWorkout{
+					ID: "s2", ScheduledDate: day.AddDate(0, 0, 1), ScheduledAt: day.AddDate(0, 0, 1), AllDay: true,
+					Timezone: "UTC", Status: domain.ScheduleStatusCanceled,
+				},
+				PlanName: "Legs",
+			},
+		}, nil).Once()
+
+		body, err := uc.FeedICS(context.Background(), "secret")
+		require.NoError(t, err)
+
+		out := string(body)
+		assert.Contains(t, out, "UID:s1@workout-tracker\r\n")
+		assert.Contains(t, out, "SUMMARY:Push Day\r\n")
+		assert.Contains(t, out, `DESCRIPTION:Bench Press 3x10 @ 60kg\nPush Up 3x15`+"\r\n")
+		assert.Contains(t, out, "DTSTART:20260310T060000Z\r\nDTEND:20260310T070000Z\r\n")
+		assert.Contains(t, out, "DTSTART;VALUE=DATE:20260311\r\nDTEND;VALUE=DATE:20260312\r\n")
+		assert.Equal(t, 1, strings.Count(out, "STATUS:CANCELLED"))
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+}
+
+func TestCalendarUsecase_GetCalendarView(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	sameInstant := func(want time.Time) interface{} {
+		return mock.MatchedBy(func(got time.Time) bool { return got.Equal(want) })
+	}
+
+	t.Run("buckets by day in user timezone", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(mocks.MockCalendarRepository)
+		seriesRepo := new(mocks.MockScheduleSeriesRepository)
+		userRepo := new(mocks.MockUserRepository)
+		schedules := usecase.NewScheduledWorkoutUsecase(new(mocks.MockScheduledWorkoutRepository), new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), userRepo, seriesRepo)
+		uc := usecase.NewCalendarUsecase(repo, schedules)
+
+		jakarta, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "Asia/Jakarta"}, nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+		repo.On("GetSchedulesBetween", mock.Anything, "u1", day(8), day(11)).Return([]domain.AgendaItem{
+			{ScheduledWorkout: domain.ScheduledWorkout{ID: "late-utc", ScheduledDate: day(9), ScheduledAt: day(9).Add(20 * time.Hour), Timezone: "UTC", Status: domain.ScheduleStatusPending}},
+			{ScheduledWorkout: domain.ScheduledWorkout{ID: "all-day", ScheduledDate: day(9), ScheduledAt: day(9), AllDay: true, Timezone: "UTC", Status: domain.ScheduleStatusMissed}},
+			{ScheduledWorkout: domain.ScheduledWorkout{ID: "outside", ScheduledDate: day(11), ScheduledAt: day(11).Add(18 * time.Hour), Timezone: "UTC", Status: domain.ScheduleStatusPending}},
+		}, nil).Once()
+
+		completed := day(9).Add(18*time.Hour + 30*time.Minute)
+		repo.On("GetCompletedSessions", mock.Anything, "u1",
+			sameInstant(time.Date(2026, 3, 9, 0, 0, 0, 0, jakarta)),
+			sameInstant(time.Date(2026, 3, 11, 0, 0, 0, 0, jakarta)),
+		).Return([]domain.WorkoutSession{{ID: "sess-1", StartedAt: completed.Add(-time.Hour), CompletedAt: &completed}}, nil).Once()
+
+		from, to := day(9), day(10)
+		view, err := uc.GetCalendarView(context.Background(), "u1", &from, &to, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Jakarta", view.Timezone)
+		require.Len(t, view.Buckets, 2)
+
+		first, second := view.Buckets[0], view.Buckets[1]
+		require.Len(t, first.Schedules, 1)
+		assert.Equal(t, "all-day", first.Schedules[0].ID)
+		assert.Equal(t, domain.ScheduleStatusMissed, first.Status())
+		assert.Empty(t, first.Sessions)
+
+		require.Len(t, second.Schedules, 1)
+		assert.Equal(t, "late-utc", second.Schedules[0].ID)
+		assert.Equal(t, 1, second.StatusCounts[domain.ScheduleStatusPending])
+		require.Len(t, second.Sessions, 1)
+		assert.Equal(t, "sess-1", second.Sessions[0].ID)
+
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("widens range to whole weeks", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(mocks.MockCalendarRepository)
+		seriesRepo := new(mocks.MockScheduleSeriesRepository)
+		userRepo := new(mocks.MockUserRepository)
+		schedules := usecase.NewScheduledWorkoutUsecase(new(mocks.MockScheduledWorkoutRepository), new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), userRepo, seriesRepo)
+		uc := usecase.NewCalendarUsecase(repo, schedules)
+
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+		repo.On("GetSchedulesBetween", mock.Anything, "u1", day(8), day(23)).Return([]domain.AgendaItem{}, nil).Once()
+		repo.On("GetCompletedSessions", mock.Anything, "u1", sameInstant(day(9)), sameInstant(day(23))).Return([]domain.WorkoutSession{}, nil).Once()
+
+		from, to := day(11), day(16)
+		view, err := uc.GetCalendarView(context.Background(), "u1", &from, &to, domain.CalendarWeek)
+		require.NoError(t, err)
+		require.Len(t, view.Buckets, 2)
+		assert.Equal(t, day(9), view.Buckets[0].Start)
+		assert.Equal(t, day(15), view.Buckets[0].End)
+		assert.Equal(t, day(16), view.Buckets[1].Start)
+		assert.Equal(t, "", view.Buckets[1].Status())
+
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("far future view stops materializing at the horizon", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(mocks.MockCalendarRepository)
+		seriesRepo := new(mocks.MockScheduleSeriesRepository)
+		userRepo := new(mocks.MockUserRepository)
+		schedules := usecase.NewScheduledWorkoutUsecase(new(mocks.MockScheduledWorkoutRepository), new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), userRepo, seriesRepo)
+		uc := usecase.NewCalendarUsecase(repo, schedules)
+
+		today := domain.CivilDate(time.Now().UTC())
+		limit := today.AddDate(0, 0, 366)
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{{
+			ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: today, Timezone: "UTC", AllDay: true, RRule: "FREQ=WEEKLY", MaterializedUntil: &limit,
+		}}, nil).Once()
+		repo.On("GetSchedulesBetween", mock.Anything, "u1", mock.Anything, mock.Anything).Return([]domain.AgendaItem{}, nil).Once()
+		repo.On("GetCompletedSessions", mock.Anything, "u1", mock.Anything, mock.Anything).Return([]domain.WorkoutSession{}, nil).Once()
+
+		from, to := time.Date(2900, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2900, 12, 31, 0, 0, 0, 0, time.UTC)
+		_, err := uc.GetCalendarView(context.Background(), "u1", &from, &to, domain.CalendarMonth)
+		require.NoError(t, err)
+
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+		seriesRepo.AssertNotCalled(t, "AddOccurrences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+
+		from, to := day(10), day(9)
+		for _, tc := range []struct {
+			from, to    *time.Time
+			granularity string
+		}{
+			{granularity: "year"},
+			{from: &from, to: &to},
+		} {
+			uc, _, _ := newCalendarUsecase()
+			_, err := uc.GetCalendarView(context.Background(), "u1", tc.from, tc.to, tc.granularity)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/repository"
+)
+
+const (
+	digestBatchSize   = 100
+	digestAgendaLimit = 20
+)
+
+type DigestUsecase struct {
+	repo       repository.DigestRepository
+	outbox     repository.MailOutboxRepository
+	reportRepo repository.ReportRepository
+	recordRepo repository.RecordRepository
+	schedules  *ScheduledWorkoutUsecase
+	renderer   domain.DigestRenderer
+}
+
+func NewDigestUsecase(repo repository.DigestRepository, outbox repository.MailOutboxRepository, reportRepo repository.ReportRepository, recordRepo repository.RecordRepository, schedules *ScheduledWorkoutUsecase, renderer domain.DigestRenderer) *DigestUsecase {
+	return &DigestUsecase{repo: repo, outbox: outbox, reportRepo: reportRepo, recordRepo: recordRepo, schedules: schedules, renderer: renderer}
+}
+
+// EnqueueDue queues the weekly digests due at now and returns how many were
+// queued.
+func (u *DigestUsecase) EnqueueDue(ctx context.Context, now time.Time) (int, error) {
+	queued := 0
+	after := ""
+	var errs []error
+	for queued < digestBatchSize {
+		limit := digestBatchSize - queued
+		recipients, err := u.repo.GetDueRecipients(ctx, now, after, limit)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		for _, r := range recipients {
+			after = r.UserID
+			ok, err := u.enqueue(ctx, r)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("digest for %s: %w", r.UserID, err))
+				continue
+			}
+			if ok {
+				queued++
+			}
+		}
+
+		if len(recipients) < limit {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return queued, fmt.Errorf("enqueue digests: %w", errors.Join(errs...))
+	}
+	return queued, nil
+}
+
+func (u *DigestUsecase) enqueue(ctx context.Context, r domain.DigestRecipient) (bool, error) {
+	d, err := u.Build(ctx, r)
+	if err != nil {
+		return false, err
+	}
+
+	content, err := u.renderer.RenderDigest(*d)
+	if err != nil {
+		return false, err
+	}
+
+	return u.outbox.Enqueue(ctx, &domain.MailMessage{
+		UserID:      r.UserID,
+		Kind:        domain.MailKindWeeklyDigest,
+		PeriodStart: r.WeekStart,
+		ToName:      r.Name,
+		ToAddress:   r.Email,
+		MailContent: content,
+	})
+}
+
+// Build gathers the digest of the week starting r.WeekStart.
+func (u *DigestUsecase) Build(ctx context.Context, r domain.DigestRecipient) (*domain.WeeklyDigest, error) {
+	loc, err := domain.LoadTimezone(r.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := u.reportRepo.GetDailyStats(ctx, r.UserID, loc.String(), r.WeekStart.AddDate(0, 0, -7), r.WeekEnd())
+	if err != nil {
+		return nil, err
+	}
+	var week, previous []domain.DailyStats
+	for _, s := range stats {
+		if s.Day.Before(r.WeekStart) {
+			previous = append(previous, s)
+		} else {
+			week = append(week, s)
+		}
+	}
+
+	start := time.Date(r.WeekStart.Year(), r.WeekStart.Month(), r.WeekStart.Day(), 0, 0, 0, 0, loc)
+	records, err := u.recordRepo.GetAchieved(ctx, r.UserID, start, start.AddDate(0, 0, 7))
+	if err != nil {
+		return nil, err
+	}
+
+	from := r.WeekStart.AddDate(0, 0, 7)
+	to := from.AddDate(0, 0, 6)
+	agenda, err := u.schedules.GetAgenda(ctx, r.UserID, domain.NewPagination(1, digestAgendaLimit), domain.ScheduledWorkoutFilter{From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.WeeklyDigest{
+		DigestRecipient: r,
+		TrainingTotals:  trainingTotals(week),
+		PreviousVolume:  trainingTotals(previous).Volume,
+		Records:         records,
+		Upcoming:        agenda.Data,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func TestDigestUsecase_EnqueueDue(t *testing.T) {
+	t.Parallel()
+
+	repo := new(mocks.MockDigestRepository)
+	outbox := new(mocks.MockMailOutboxRepository)
+	reportRepo := new(mocks.MockReportRepository)
+	recordRepo := new(mocks.MockRecordRepository)
+	scheduledRepo := new(mocks.MockScheduledWorkoutRepository)
+	seriesRepo := new(mocks.MockScheduleSeriesRepository)
+	renderer := new(mocks.MockDigestRenderer)
+	schedules := usecase.NewScheduledWorkoutUsecase(scheduledRepo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), seriesRepo)
+	uc := usecase.NewDigestUsecase(repo, outbox, reportRepo, recordRepo, schedules, renderer)
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	now := time.Date(2026, 3, 9, 2, 0, 0, 0, time.UTC)
+	recipient := domain.DigestRecipient{UserID: "u1", Name: "Budi", Email: "budi@example.com", Timezone: "Asia/Jakarta", WeekStart: day(2)}
+	repo.On("GetDueRecipients", mock.Anything, now, "", 100).Return([]domain.DigestRecipient{recipient}, nil).Once()
+
+	reportRepo.On("GetDailyStats", mock.Anything, "u1", "Asia/Jakarta", time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), day(8)).Return([]domain.DailyStats{
+		{Day: day(1), Sessions: 1, Volume: 4000},
+		{Day: day(3), Sessions: 1, Duration: time.Hour, Volume: 3000},
+		{Day: day(5), Sessions: 2, Duration: time.Hour, Volume: 2000},
+	}, nil).Once()
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, loc)
+	end := time.Date(2026, 3, 9, 0, 0, 0, 0, loc)
+	records := []domain.PersonalRecord{{ExerciseName: "Squat", Type: domain.RecordMaxWeight, Value: 140}}
+	recordRepo.On("GetAchieved", mock.Anything, "u1", mock.MatchedBy(start.Equal), mock.MatchedBy(end.Equal)).Return(records, nil).Once()
+
+	from, to := day(9), day(15)
+	seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+	upcoming := []domain.AgendaItem{{PlanName: "Push Day"}}
+	scheduledRepo.On("GetAgenda", mock.Anything, "u1", mock.Anything, domain.ScheduledWorkoutFilter{
+		From: &from, To: &to, Status: domain.ScheduleStatusPending, Sort: domain.SortAsc,
+	}).Return(domain.NewPaginatedResult(upcoming, 1, domain.NewPagination(1, 20)), nil).Once()
+
+	content := domain.MailContent{Subject: "Your training week", Text: "text", HTML: "<p>html</p>"}
+	renderer.On("RenderDigest", mock.MatchedBy(func(d domain.WeeklyDigest) bool {
+		return d.Sessions == 3 && d.Duration == 2*time.Hour && d.Volume == 5000 && d.PreviousVolume == 4000 &&
+			len(d.Records) == 1 && len(d.Upcoming) == 1
+	})).Return(content, nil).Once()
+	outbox.On("Enqueue", mock.Anything, &domain.MailMessage{
+		UserID:      "u1",
+		Kind:        domain.MailKindWeeklyDigest,
+		PeriodStart: day(2),
+		ToName:      "Budi",
+		ToAddress:   "budi@example.com",
+		MailContent: content,
+	}).Return(true, nil).Once()
+
+	queued, err := uc.EnqueueDue(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	repo.AssertExpectations(t)
+	reportRepo.AssertExpectations(t)
+	recordRepo.AssertExpectations(t)
+	scheduledRepo.AssertExpectations(t)
+	renderer.AssertExpectations(t)
+	outbox.AssertExpectations(t)
+}
+
+func TestDigestUsecase_EnqueueDueSkipsFailingRecipients(t *testing.T) {
+	t.Parallel()
+
+	repo := new(mocks.MockDigestRepository)
+	outbox := new(mocks.MockMailOutboxRepository)
+	schedules := usecase.NewScheduledWorkoutUsecase(new(mocks.MockScheduledWorkoutRepository), new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), new(mocks.MockScheduleSeriesRepository))
+	uc := usecase.NewDigestUsecase(repo, outbox, new(mocks.MockReportRepository), new(mocks.MockRecordRepository), schedules, new(mocks.MockDigestRenderer))
+
+	now := time.Date(2026, 3, 9, 2, 0, 0, 0, time.UTC)
+	broken := make([]domain.DigestRecipient, 100)
+	for i := range broken {
+		broken[i] = domain.DigestRecipient{UserID: fmt.Sprintf("u%03d", i), Timezone: "Not/AZone"}
+	}
+	repo.On("GetDueRecipients", mock.Anything, now, "", 100).Return(broken, nil).Once()
+	repo.On("GetDueRecipients", mock.Anything, now, "u099", 100).Return([]domain.DigestRecipient{}, nil).Once()
+
+	queued, err := uc.EnqueueDue(context.Background(), now)
+	require.Error(t, err)
+	assert.Equal(t, 0, queued)
+	repo.AssertExpectations(t)
+	outbox.AssertExpectations(t)
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/repository"
+)
+
+const (
+	mailBatchSize    = 50
+	mailClaimTimeout = 10 * time.Minute
+	maxMailAttempts  = 5
+	mailRetryBase    = 5 * time.Minute
+)
+
+type MailUsecase struct {
+	outbox    repository.MailOutboxRepository
+	transport domain.MailTransport
+}
+
+func NewMailUsecase(outbox repository.MailOutboxRepository, transport domain.MailTransport) *MailUsecase {
+	return &MailUsecase{outbox: outbox, transport: transport}
+}
+
+// SendDue delivers the outbox messages due at now and returns how many were
+// sent.
+func (u *MailUsecase) SendDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := u.outbox.ClaimDue(ctx, now, now.Add(mailClaimTimeout), mailBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("send mail: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, m := range due {
+		if err := u.transport.Send(ctx, m); err != nil {
+			errs = append(errs, fmt.Errorf("send %s: %w", m.ID, err))
+
+			var retryAt *time.Time
+			if m.Attempts < maxMailAttempts {
+				at := now.Add(mailRetryBase << (m.Attempts - 1))
+				retryAt = &at
+			}
+			if err := u.outbox.MarkFailed(ctx, m.ID, err.Error(), retryAt); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if err := u.outbox.MarkSent(ctx, m.ID, time.Now()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+
+	if len(errs) > 0 {
+		return sent, fmt.Errorf("send mail: %w", errors.Join(errs...))
+	}
+	return sent, nil
+}
@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func TestMailUsecase_SendDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)
+	retryAt := func(at time.Time) interface{} {
+		return mock.MatchedBy(func(t *time.Time) bool { return t != nil && t.Equal(at) })
+	}
+
+	tests := []struct {
+		name         string
+		attempts     int
+		sendErr      error
+		retryAt      interface{}
+		expectedSent int
+	}{
+		{name: "sends", attempts: 1, expectedSent: 1},
+		{name: "retries failed delivery", attempts: 2, sendErr: errors.New("smtp down"), retryAt: retryAt(now.Add(10 * time.Minute))},
+		{name: "gives up after the last attempt", attempts: 5, sendErr: errors.New("smtp down"), retryAt: (*time.Time)(nil)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outbox := new(mocks.MockMailOutboxRepository)
+			transport := new(mocks.MockMailTransport)
+			uc := usecase.NewMailUsecase(outbox, transport)
+
+			msg := domain.MailMessage{ID: "m1", ToAddress: "budi@example.com", Attempts: tt.attempts}
+			outbox.On("ClaimDue", mock.Anything, now, now.Add(10*time.Minute), mock.Anything).Return([]domain.MailMessage{msg}, nil).Once()
+			transport.On("Send", mock.Anything, msg).Return(tt.sendErr).Once()
+			if tt.sendErr == nil {
+				outbox.On("MarkSent", mock.Anything, "m1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+			} else {
+				outbox.On("MarkFailed", mock.Anything, "m1", "smtp down", tt.retryAt).Return(nil).Once()
+			}
+
+			sent, err := uc.SendDue(context.Background(), now)
+			assert.Equal(t, tt.expectedSent, sent)
+			if tt.sendErr != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			outbox.AssertExpectations(t)
+			transport.AssertExpectations(t)
+		})
+	}
+}
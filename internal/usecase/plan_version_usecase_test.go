@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func TestWorkoutUsecase_DiffPlanVersions(t *testing.T) {
+	t.Parallel()
+
+	v1 := &domain.WorkoutPlanVersion{Version: 1, Name: "Push", Exercises: []domain.WorkoutPlanExerciseDetail{
+		{WorkoutPlanExercise: domain.WorkoutPlanExercise{ExerciseID: "e1", Sets: 3, Reps: 8}},
+	}}
+	v2 := &domain.WorkoutPlanVersion{Version: 2, Name: "Push", Exercises: []domain.WorkoutPlanExerciseDetail{
+		{WorkoutPlanExercise: domain.WorkoutPlanExercise{ExerciseID: "e1", Sets: 5, Reps: 8}},
+		{WorkoutPlanExercise: domain.WorkoutPlanExercise{ExerciseID: "e2", Sets: 3, Reps: 10}},
+	}}
+
+	tests := []struct {
+		name    string
+		plan    *domain.WorkoutPlan
+		from    int
+		to      int
+		setup   func(repo *mocks.MockWorkoutRepository)
+		wantErr error
+	}{
+		{
+			name: "success",
+			plan: &domain.WorkoutPlan{ID: "p1"},
+			from: 1,
+			to:   2,
+			setup: func(repo *mocks.MockWorkoutRepository) {
+				repo.On("GetPlanVersion", mock.Anything, "p1", 1).Return(v1, nil).Once()
+				repo.On("GetPlanVersion", mock.Anything, "p1", 2).Return(v2, nil).Once()
+			},
+		},
+		{name: "plan not found", from: 1, to: 2, wantErr: domain.ErrNotFound},
+		{
+			name: "version not found",
+			plan: &domain.WorkoutPlan{ID: "p1"},
+			from: 1,
+			to:   7,
+			setup: func(repo *mocks.MockWorkoutRepository) {
+				repo.On("GetPlanVersion", mock.Anything, "p1", 1).Return(v1, nil).Once()
+				repo.On("GetPlanVersion", mock.Anything, "p1", 7).Return(nil, nil).Once()
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{name: "invalid version", plan: &domain.WorkoutPlan{ID: "p1"}, from: 0, to: 2, wantErr: domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockWorkoutRepository)
+			if tt.plan != nil {
+				repo.On("GetPlanByID", mock.Anything, "p1", "u1").Return(tt.plan, nil).Once()
+			} else {
+				repo.On("GetPlanByID", mock.Anything, "p1", "u1").Return(nil, nil).Once()
+			}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			diff, err := usecase.NewWorkoutUsecase(repo).DiffPlanVersions(context.Background(), "u1", "p1", tt.from, tt.to)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, diff.From)
+				assert.Equal(t, 2, diff.To)
+				require.Len(t, diff.Added, 1)
+				assert.Equal(t, "e2", diff.Added[0].ExerciseID)
+				require.Len(t, diff.Changed, 1)
+				assert.Equal(t, []string{domain.PlanFieldSets}, diff.Changed[0].Fields)
+				assert.Empty(t, diff.Removed)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkoutUsecase_RestorePlanVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		version    int
+		restoreErr error
+		wantErr    error
+	}{
+		{name: "success", version: 1},
+		{name: "version not found", version: 9, restoreErr: sql.ErrNoRows, wantErr: domain.ErrNotFound},
+		{name: "invalid version", version: 0, wantErr: domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockWorkoutRepository)
+			if tt.version > 0 {
+				repo.On("RestorePlanVersion", mock.Anything, "p1", "u1", tt.version).Return(tt.restoreErr).Once()
+			}
+			if tt.wantErr == nil {
+				repo.On("GetPlanDetail", mock.Anything, "p1", "u1").Return(&domain.WorkoutPlanDetail{WorkoutPlan: domain.WorkoutPlan{ID: "p1", Name: "Push"}}, nil).Once()
+			}
+
+			plan, err := usecase.NewWorkoutUsecase(repo).RestorePlanVersion(context.Background(), "u1", "p1", tt.version)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "Push", plan.Name)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/repository"
+)
+
+type RecordUsecase struct {
+	repo repository.RecordRepository
+}
+
+func NewRecordUsecase(repo repository.RecordRepository) *RecordUsecase {
+	return &RecordUsecase{repo: repo}
+}
+
+// GetRecords lists the user's standing personal records, by exercise name,
+// and every record they have set, optionally for one exercise.
+func (u *RecordUsecase) GetRecords(ctx context.Context, userID, exerciseID string) (*domain.RecordBook, error) {
+	userID = strings.TrimSpace(userID)
+	exerciseID = strings.TrimSpace(exerciseID)
+	if userID == "" {
+		return nil, fmt.Errorf("get records: %w", domain.ErrInvalidInput)
+	}
+
+	var exerciseIDs []string
+	if exerciseID != "" {
+		// Exercise IDs are UUIDs; normalize them so the lookups can compare
+		// and cast them safely.
+		id, err := uuid.Parse(exerciseID)
+		if err != nil {
+			return nil, fmt.Errorf("get records: %w", domain.ErrInvalidInput)
+		}
+		exerciseID = id.String()
+		exerciseIDs = []string{exerciseID}
+	}
+
+	current, err := u.repo.GetCurrent(ctx, userID, exerciseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get records: %w", err)
+	}
+	sort.SliceStable(current, func(i, j int) bool {
+		return current[i].ExerciseName < current[j].ExerciseName
+	})
+
+	history, err := u.repo.GetHistory(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("get records: %w", err)
+	}
+
+	return &domain.RecordBook{Current: current, History: history}, nil
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func TestRecordUsecase_GetRecords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		exerciseID  string
+		exerciseIDs []string
+		repoErr     error
+	}{
+		{name: "all exercises"},
+		{name: "one exercise", exerciseID: "0B6C3D1E-1F2A-4B5C-8D9E-0F1A2B3C4D5E", exerciseIDs: []string{"0b6c3d1e-1f2a-4b5c-8d9e-0f1a2b3c4d5e"}},
+		{name: "repository error", repoErr: errors.New("db down")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockRecordRepository)
+			if tt.repoErr != nil {
+				repo.On("GetCurrent", mock.Anything, "u1", tt.exerciseIDs).Return(nil, tt.repoErr).Once()
+			} else {
+				repo.On("GetCurrent", mock.Anything, "u1", tt.exerciseIDs).Return([]domain.PersonalRecord{
+					{ExerciseName: "Squat", Type: domain.RecordMaxWeight},
+					{ExerciseName: "Bench Press", Type: domain.RecordMaxWeight},
+				}, nil).Once()
+				repo.On("GetHistory", mock.Anything, "u1", strings.ToLower(tt.exerciseID)).Return([]domain.PersonalRecord{{ExerciseName: "Squat"}}, nil).Once()
+			}
+
+			book, err := usecase.NewRecordUsecase(repo).GetRecords(context.Background(), "u1", tt.exerciseID)
+			if tt.repoErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.repoErr))
+			} else {
+				require.NoError(t, err)
+				require.Len(t, book.Current, 2)
+				assert.Equal(t, "Bench Press", book.Current[0].ExerciseName)
+				assert.Len(t, book.History, 1)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecordUsecase_GetRecordsMalformedExerciseID(t *testing.T) {
+	t.Parallel()
+
+	repo := new(mocks.MockRecordRepository)
+	_, err := usecase.NewRecordUsecase(repo).GetRecords(context.Background(), "u1", "not-a-uuid")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/repository"
+)
+
+const (
+	reminderBatchSize   = 100
+	maxReminderAttempts = 3
+	reminderRetryBase   = 5 * time.Minute
+)
+
+type ReminderUsecase struct {
+	repo      repository.ReminderRepository
+	notifiers []domain.Notifier
+}
+
+func NewReminderUsecase(repo repository.ReminderRepository, notifiers ...domain.Notifier) *ReminderUsecase {
+	return &ReminderUsecase{repo: repo, notifiers: notifiers}
+}
+
+// SendDue delivers the reminders due at now and returns how many were sent.
+func (u *ReminderUsecase) SendDue(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	var errs []error
+
+	for _, n := range u.notifiers {
+		channel := n.Channel()
+		due, err := u.repo.GetDue(ctx, channel, now, reminderBatchSize)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, r := range due {
+			claimed, err := u.repo.Claim(ctx, r.ScheduleID, channel, r.ScheduledAt, now)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+
+			if err := n.Notify(ctx, r); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s via %s: %w", r.ScheduleID, channel, err))
+
+				var retryAt *time.Time
+				if r.Attempts+1 < maxReminderAttempts {
+					at := now.Add(reminderRetryBase << r.Attempts)
+					retryAt = &at
+				}
+				if err := u.repo.MarkFailed(ctx, r.ScheduleID, channel, r.ScheduledAt, err.Error(), retryAt); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			sent++
+		}
+	}
+
+	if len(errs) > 0 {
+		return sent, fmt.Errorf("send reminders: %w", errors.Join(errs...))
+	}
+	return sent, nil
+}
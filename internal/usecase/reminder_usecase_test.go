@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func TestReminderUsecase_SendDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	r1 := domain.Reminder{ScheduleID: "s1", Email: "a@example.com", ScheduledAt: now.Add(30 * time.Minute)}
+	r2 := domain.Reminder{ScheduleID: "s2", Email: "b@example.com", ScheduledAt: now.Add(45 * time.Minute)}
+	retried := r2
+	retried.Attempts = 2
+	retryAt := now.Add(5 * time.Minute)
+
+	tests := []struct {
+		name         string
+		second       domain.Reminder
+		claimed      map[string]bool
+		notifyErr    map[string]error
+		retryAt      map[string]*time.Time
+		expectedSent int
+		expectErr    bool
+	}{
+		{name: "sends all", second: r2, claimed: map[string]bool{"s1": true, "s2": true}, expectedSent: 2},
+		{name: "skips claimed elsewhere", second: r2, claimed: map[string]bool{"s1": false, "s2": true}, expectedSent: 1},
+		{
+			name:         "backs off failed delivery",
+			second:       r2,
+			claimed:      map[string]bool{"s1": true, "s2": true},
+			notifyErr:    map[string]error{"s1": errors.New("smtp down")},
+			retryAt:      map[string]*time.Time{"s1": &retryAt},
+			expectedSent: 1,
+			expectErr:    true,
+		},
+		{
+			name:         "gives up after last attempt",
+			second:       retried,
+			claimed:      map[string]bool{"s1": true, "s2": true},
+			notifyErr:    map[string]error{"s2": errors.New("smtp down")},
+			retryAt:      map[string]*time.Time{"s2": nil},
+			expectedSent: 1,
+			expectErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockReminderRepository)
+			notifier := new(mocks.MockNotifier)
+			uc := usecase.NewReminderUsecase(repo, notifier)
+
+			notifier.On("Channel").Return("email")
+			due := []domain.Reminder{r1, tt.second}
+			repo.On("GetDue", mock.Anything, "email", now, mock.Anything).Return(due, nil).Once()
+			for _, r := range due {
+				repo.On("Claim", mock.Anything, r.ScheduleID, "email", r.ScheduledAt, now).Return(tt.claimed[r.ScheduleID], nil).Once()
+				if !tt.claimed[r.ScheduleID] {
+					continue
+				}
+				notifier.On("Notify", mock.Anything, r).Return(tt.notifyErr[r.ScheduleID]).Once()
+				if tt.notifyErr[r.ScheduleID] != nil {
+					repo.On("MarkFailed", mock.Anything, r.ScheduleID, "email", r.ScheduledAt, "smtp down", tt.retryAt[r.ScheduleID]).Return(nil).Once()
+				}
+			}
+
+			sent, err := uc.SendDue(context.Background(), now)
+			assert.Equal(t, tt.expectedSent, sent)
+			if tt.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
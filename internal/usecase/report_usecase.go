@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/repository"
+)
+
+const (
+	// defaultReportDays is the length of a report when no range is given.
+	defaultReportDays = 28
+	maxReportDays     = 366
+)
+
+type ReportUsecase struct {
+	repo         repository.ReportRepository
+	userRepo     domain.UserRepository
+	exerciseRepo domain.ExerciseRepository
+	schedules    *ScheduledWorkoutUsecase
+}
+
+func NewReportUsecase(repo repository.ReportRepository, userRepo domain.UserRepository, exerciseRepo domain.ExerciseRepository, schedules *ScheduledWorkoutUsecase) *ReportUsecase {
+	return &ReportUsecase{repo: repo, userRepo: userRepo, exerciseRepo: exerciseRepo, schedules: schedules}
+}
+
+// Summary totals the user's finished sessions started between from and to,
+// inclusive calendar days in the user's timezone, with a breakdown per week
+// starting on Monday. Without a range it covers the last four weeks.
+func (u *ReportUsecase) Summary(ctx context.Context, userID string, from, to *time.Time) (*domain.ReportSummary, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("report summary: %w", domain.ErrInvalidInput)
+	}
+
+	first, last, loc, err := u.reportRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("report summary: %w", err)
+	}
+
+	stats, err := u.repo.GetDailyStats(ctx, userID, loc.String(), first, last)
+	if err != nil {
+		return nil, fmt.Errorf("report summary: %w", err)
+	}
+
+	summary := &domain.ReportSummary{From: first, To: last, Timezone: loc.String(), Weeks: []domain.WeeklySummary{}}
+	summary.TrainingTotals = trainingTotals(stats)
+
+	byWeek := map[time.Time][]domain.DailyStats{}
+	for _, s := range stats {
+		week := domain.CalendarPeriodStart(s.Day, domain.CalendarWeek)
+		byWeek[week] = append(byWeek[week], s)
+	}
+	for week := domain.CalendarPeriodStart(first, domain.CalendarWeek); !week.After(last); week = week.AddDate(0, 0, 7) {
+		summary.Weeks = append(summary.Weeks, domain.WeeklySummary{WeekStart: week, TrainingTotals: trainingTotals(byWeek[week])})
+	}
+
+	return summary, nil
+}
+
+// reportRange resolves the inclusive calendar days a report covers and the
+// user's timezone. Without a range it ends today and spans four weeks.
+func (u *ReportUsecase) reportRange(ctx context.Context, userID string, from, to *time.Time) (time.Time, time.Time, *time.Location, error) {
+	loc, err := userLocation(ctx, u.userRepo, userID)
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+
+	last := domain.CivilDate(time.Now().In(loc))
+	if to != nil {
+		last = domain.CivilDate(*to)
+	}
+	first := last.AddDate(0, 0, -(defaultReportDays - 1))
+	if from != nil {
+		first = domain.CivilDate(*from)
+	}
+	if last.Before(first) || last.Sub(first) > maxReportDays*24*time.Hour {
+		return time.Time{}, time.Time{}, nil, domain.ErrInvalidInput
+	}
+
+	return first, last, loc, nil
+}
+
+// optionalDayBounds returns the instants starting from and ending to in loc,
+// either of which may be open.
+func optionalDayBounds(from, to *time.Time, loc *time.Location) (*time.Time, *time.Time) {
+	var start, end *time.Time
+	if from != nil {
+		d := domain.CivilDate(*from)
+		v := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+		start = &v
+	}
+	if to != nil {
+		d := domain.CivilDate(*to)
+		v := time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, 0, loc)
+		end = &v
+	}
+	return start, end
+}
+
+func trainingTotals(stats []domain.DailyStats) domain.TrainingTotals {
+	var t domain.TrainingTotals
+	exercises := map[string]struct{}{}
+	for _, s := range stats {
+		t.Sessions += s.Sessions
+		t.Duration += s.Duration
+		t.Volume += s.Volume
+		for _, id := range s.ExerciseIDs {
+			exercises[id] = struct{}{}
+		}
+	}
+	t.DistinctExercises = len(exercises)
+	return t
+}
+
+// Progress charts an exercise across the user's finished sessions, one point
+// per session or per week or month in the user's timezone. from and to are
+// optional inclusive calendar days.
+func (u *ReportUsecase) Progress(ctx context.Context, userID, exerciseID string, from, to *time.Time, formula, granularity string) (*domain.ProgressReport, error) {
+	exerciseID = strings.TrimSpace(exerciseID)
+	formula = strings.ToLower(strings.TrimSpace(formula))
+	granularity = strings.ToLower(strings.TrimSpace(granularity))
+	if formula == "" {
+		formula = domain.OneRepMaxEpley
+	}
+	if granularity == "" {
+		granularity = domain.ProgressPerSession
+	}
+	if userID == "" || exerciseID == "" || !domain.IsValidOneRepMaxFormula(formula) {
+		return nil, fmt.Errorf("report progress: %w", domain.ErrInvalidInput)
+	}
+	if granularity != domain.ProgressPerSession && granularity != domain.CalendarWeek && granularity != domain.CalendarMonth {
+		return nil, fmt.Errorf("report progress: %w", domain.ErrInvalidInput)
+	}
+	if from != nil && to != nil && domain.CivilDate(*to).Before(domain.CivilDate(*from)) {
+		return nil, fmt.Errorf("report progress: %w", domain.ErrInvalidInput)
+	}
+
+	exercise, err := u.exerciseRepo.GetByID(ctx, exerciseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("report progress: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("report progress: %w", err)
+	}
+
+	loc, err := userLocation(ctx, u.userRepo, userID)
+	if err != nil {
+		return nil, fmt.Errorf("report progress: %w", err)
+	}
+
+	start, end := optionalDayBounds(from, to, loc)
+	sets, err := u.repo.GetPerformedSets(ctx, userID, exerciseID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("report progress: %w", err)
+	}
+
+	report := &domain.ProgressReport{
+		Exercise:    *exercise,
+		Formula:     formula,
+		Granularity: granularity,
+		Timezone:    loc.String(),
+		Points:      []domain.ProgressPoint{},
+	}
+
+	// Sets arrive ordered by session start, so each point is contiguous.
+	bestRM := -1.0
+	lastSession := ""
+	for _, s := range sets {
+		key := s.SessionID
+		periodStart := domain.CivilDate(s.StartedAt.In(loc))
+		if granularity != domain.ProgressPerSession {
+			periodStart = domain.CalendarPeriodStart(periodStart, granularity)
+			key = periodStart.Format("2006-01-02")
+		}
+
+		n := len(report.Points)
+		if n == 0 || key != progressKey(report.Points[n-1], granularity) {
+			report.Points = append(report.Points, domain.ProgressPoint{Start: periodStart})
+			n++
+			bestRM = -1
+		}
+		p := &report.Points[n-1]
+		if granularity == domain.ProgressPerSession {
+			p.SessionID = s.SessionID
+		}
+		if s.SessionID != lastSession {
+			lastSession = s.SessionID
+			p.Sessions++
+		}
+
+		rm := domain.EstimateOneRepMax(formula, s.Weight, s.Reps)
+		if rm > bestRM {
+			bestRM = rm
+			p.BestSet = s
+			p.OneRepMax = rm
+		}
+		if s.Weight > p.TopWeight {
+			p.TopWeight = s.Weight
+		}
+		p.Volume += float64(s.Reps) * s.Weight
+	}
+
+	return report, nil
+}
+
+func progressKey(p domain.ProgressPoint, granularity string) string {
+	if granularity == domain.ProgressPerSession {
+		return p.SessionID
+	}
+	return p.Start.Format("2006-01-02")
+}
+
+// Balance spreads the user's working sets and volume over muscle groups and
+// categories, per week and for the whole range, and checks them against
+// domain.BalanceRules. The range works as in Summary.
+func (u *ReportUsecase) Balance(ctx context.Context, userID string, from, to *time.Time) (*domain.BalanceReport, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("report balance: %w", domain.ErrInvalidInput)
+	}
+
+	first, last, loc, err := u.reportRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("report balance: %w", err)
+	}
+
+	work, err := u.repo.GetMuscleWork(ctx, userID, loc.String(), first, last)
+	if err != nil {
+		return nil, fmt.Errorf("report balance: %w", err)
+	}
+
+	exercises, err := u.exerciseRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("report balance: %w", err)
+	}
+
+	report := &domain.BalanceReport{
+		From:         first,
+		To:           last,
+		Timezone:     loc.String(),
+		MuscleGroups: map[string]domain.TrainingLoad{},
+		Categories:   map[string]domain.TrainingLoad{},
+		Weeks:        []domain.WeeklyBalance{},
+		Ratios:       make([]domain.BalanceRatio, 0, len(domain.BalanceRules)),
+		Neglected:    []string{},
+	}
+
+	weeks := map[time.Time]int{}
+	for week := domain.CalendarPeriodStart(first, domain.CalendarWeek); !week.After(last); week = week.AddDate(0, 0, 7) {
+		weeks[week] = len(report.Weeks)
+		report.Weeks = append(report.Weeks, domain.WeeklyBalance{
+			WeekStart:    week,
+			MuscleGroups: map[string]domain.TrainingLoad{},
+			Categories:   map[string]domain.TrainingLoad{},
+		})
+	}
+
+	for _, w := range work {
+		report.MuscleGroups[w.MuscleGroup] = report.MuscleGroups[w.MuscleGroup].Add(w.TrainingLoad)
+		report.Categories[w.Category] = report.Categories[w.Category].Add(w.TrainingLoad)
+
+		i, ok := weeks[domain.CalendarPeriodStart(w.Day, domain.CalendarWeek)]
+		if !ok {
+			continue
+		}
+		week := &report.Weeks[i]
+		week.MuscleGroups[w.MuscleGroup] = week.MuscleGroups[w.MuscleGroup].Add(w.TrainingLoad)
+		week.Categories[w.Category] = week.Categories[w.Category].Add(w.TrainingLoad)
+	}
+
+	for _, rule := range domain.BalanceRules {
+		report.Ratios = append(report.Ratios, rule.Evaluate(report.MuscleGroups))
+	}
+
+	seen := map[string]struct{}{}
+	for _, e := range exercises {
+		group := e.MuscleGroup
+		if group == "" {
+			continue
+		}
+		if _, ok := seen[group]; ok {
+			continue
+		}
+		seen[group] = struct{}{}
+		if report.MuscleGroups[group].Sets == 0 {
+			report.Neglected = append(report.Neglected, group)
+		}
+	}
+	sort.Strings(report.Neglected)
+
+	return report, nil
+}
+
+// Adherence compares the user's scheduled workouts with their finished
+// sessions per week or month, widening the range to whole periods, and
+// reports their daily and weekly training streaks. The range otherwise
+// works as in Summary.
+func (u *ReportUsecase) Adherence(ctx context.Context, userID string, from, to *time.Time, granularity string) (*domain.AdherenceReport, error) {
+	granularity = strings.ToLower(strings.TrimSpace(granularity))
+	if granularity == "" {
+		granularity = domain.CalendarWeek
+	}
+	if userID == "" || (granularity != domain.CalendarWeek && granularity != domain.CalendarMonth) {
+		return nil, fmt.Errorf("report adherence: %w", domain.ErrInvalidInput)
+	}
+
+	first, last, loc, err := u.reportRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("report adherence: %w", err)
+	}
+	first = domain.CalendarPeriodStart(first, granularity)
+	last = domain.CalendarPeriodNext(domain.CalendarPeriodStart(last, granularity), granularity).AddDate(0, 0, -1)
+
+	// Occurrences after today cannot have been adhered to yet, so there is
+	// no need to store them for this report.
+	through := last
+	if today := domain.CivilDate(time.Now().In(loc)); through.After(today) {
+		through = today
+	}
+	if err := u.schedules.MaterializeSeries(ctx, userID, &through); err != nil {
+		return nil, fmt.Errorf("report adherence: %w", err)
+	}
+
+	schedules, err := u.repo.GetScheduleStatuses(ctx, userID, first, last)
+	if err != nil {
+		return nil, fmt.Errorf("report adherence: %w", err)
+	}
+
+	stats, err := u.repo.GetDailyStats(ctx, userID, loc.String(), first, last)
+	if err != nil {
+		return nil, fmt.Errorf("report adherence: %w", err)
+	}
+
+	days, err := u.repo.GetTrainingDays(ctx, userID, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("report adherence: %w", err)
+	}
+
+	report := &domain.AdherenceReport{
+		From:        first,
+		To:          last,
+		Timezone:    loc.String(),
+		Granularity: granularity,
+		Totals:      domain.AdherencePeriod{Start: first, End: last},
+		Periods:     []domain.AdherencePeriod{},
+	}
+
+	periods := map[time.Time]int{}
+	for p := first; !p.After(last); p = domain.CalendarPeriodNext(p, granularity) {
+		periods[p] = len(report.Periods)
+		report.Periods = append(report.Periods, domain.AdherencePeriod{
+			Start: p,
+			End:   domain.CalendarPeriodNext(p, granularity).AddDate(0, 0, -1),
+		})
+	}
+
+	for _, sw := range schedules {
+		report.Totals.Count(sw.Status)
+		if i, ok := periods[domain.CalendarPeriodStart(sw.ScheduledDate, granularity)]; ok {
+			report.Periods[i].Count(sw.Status)
+		}
+	}
+	for _, s := range stats {
+		report.Totals.Sessions += s.Sessions
+		if i, ok := periods[domain.CalendarPeriodStart(s.Day, granularity)]; ok {
+			report.Periods[i].Sessions += s.Sessions
+		}
+	}
+
+	today := domain.CivilDate(time.Now().In(loc))
+	report.DailyStreak = domain.ComputeStreak(days, today, domain.CalendarDay)
+	report.WeeklyStreak = domain.ComputeStreak(days, today, domain.CalendarWeek)
+
+	return report, nil
+}
+
+// SessionHistory lists every set of the user's finished sessions started on
+// the calendar days from through to, for exports. The range works as in
+// Summary, so an export never spans more than maxReportDays.
+func (u *ReportUsecase) SessionHistory(ctx context.Context, userID string, from, to *time.Time) (*domain.SessionHistory, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("session history: %w", domain.ErrInvalidInput)
+	}
+
+	first, last, loc, err := u.reportRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("session history: %w", err)
+	}
+
+	start, end := optionalDayBounds(&first, &last, loc)
+	entries, err := u.repo.GetSessionLog(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("session history: %w", err)
+	}
+
+	return &domain.SessionHistory{Timezone: loc.String(), From: &first, To: &last, Entries: entries}, nil
+}
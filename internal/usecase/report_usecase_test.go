@@ -0,0 +1,333 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+func newReportUsecase() (*usecase.ReportUsecase, *mocks.MockReportRepository, *mocks.MockUserRepository, *mocks.MockExerciseRepository, *mocks.MockScheduleSeriesRepository) {
+	repo := new(mocks.MockReportRepository)
+	userRepo := new(mocks.MockUserRepository)
+	exerciseRepo := new(mocks.MockExerciseRepository)
+	seriesRepo := new(mocks.MockScheduleSeriesRepository)
+	schedules := usecase.NewScheduledWorkoutUsecase(new(mocks.MockScheduledWorkoutRepository), new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), userRepo, seriesRepo)
+	return usecase.NewReportUsecase(repo, userRepo, exerciseRepo, schedules), repo, userRepo, exerciseRepo, seriesRepo
+}
+
+func TestReportUsecase_Summary(t *testing.T) {
+	t.Parallel()
+
+	day := func(y int, m time.Month, d int) *time.Time {
+		v := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, userRepo, _, _ := newReportUsecase()
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1"}, nil).Once()
+
+		_, err := uc.Summary(context.Background(), "u1", day(2026, 3, 10), day(2026, 3, 1))
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("totals and weeks", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, userRepo, _, _ := newReportUsecase()
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "Asia/Jakarta"}, nil).Once()
+
+		repo.On("GetDailyStats", mock.Anything, "u1", "Asia/Jakarta", *day(2026, 3, 2), *day(2026, 3, 15)).Return([]domain.DailyStats{
+			{Day: *day(2026, 3, 3), Sessions: 1, Duration: time.Hour, Volume: 1800, ExerciseIDs: []string{"e1", "e2"}},
+			{Day: *day(2026, 3, 9), Sessions: 1, Duration: 30 * time.Minute, Volume: 500, ExerciseIDs: []string{"e2", "e3"}},
+		}, nil).Once()
+
+		summary, err := uc.Summary(context.Background(), "u1", day(2026, 3, 2), day(2026, 3, 15))
+		require.NoError(t, err)
+
+		assert.Equal(t, "Asia/Jakarta", summary.Timezone)
+		assert.Equal(t, 2, summary.Sessions)
+		assert.Equal(t, 90*time.Minute, summary.Duration)
+		assert.Equal(t, 2300.0, summary.Volume)
+		assert.Equal(t, 3, summary.DistinctExercises)
+
+		require.Len(t, summary.Weeks, 2)
+		assert.Equal(t, *day(2026, 3, 2), summary.Weeks[0].WeekStart)
+		assert.Equal(t, 1, summary.Weeks[0].Sessions)
+		assert.Equal(t, 2, summary.Weeks[0].DistinctExercises)
+		assert.Equal(t, *day(2026, 3, 9), summary.Weeks[1].WeekStart)
+		assert.Equal(t, 500.0, summary.Weeks[1].Volume)
+		repo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestReportUsecase_Progress(t *testing.T) {
+	t.Parallel()
+
+	first := time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC)
+	second := time.Date(2026, 3, 5, 1, 0, 0, 0, time.UTC)
+	sets := []domain.PerformedSet{
+		{SessionID: "s1", StartedAt: first, Reps: 5, Weight: 100},
+		{SessionID: "s1", StartedAt: first, Reps: 1, Weight: 110},
+		{SessionID: "s2", StartedAt: second, Reps: 3, Weight: 105},
+	}
+
+	tests := []struct {
+		name        string
+		formula     string
+		granularity string
+		exerciseErr error
+		expectedErr error
+		assert      func(t *testing.T, r *domain.ProgressReport)
+	}{
+		{
+			name:        "invalid formula",
+			formula:     "wathan",
+			expectedErr: domain.ErrInvalidInput,
+		},
+		{
+			name:        "unknown exercise",
+			exerciseErr: sql.ErrNoRows,
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name: "per session",
+			assert: func(t *testing.T, r *domain.ProgressReport) {
+				assert.Equal(t, domain.OneRepMaxEpley, r.Formula)
+				require.Len(t, r.Points, 2)
+				assert.Equal(t, "s1", r.Points[0].SessionID)
+				assert.Equal(t, 110.0, r.Points[0].TopWeight)
+				assert.Equal(t, 610.0, r.Points[0].Volume)
+				// 100x5 estimates above the 110 single.
+				assert.Equal(t, 5, r.Points[0].BestSet.Reps)
+				assert.InDelta(t, 116.667, r.Points[0].OneRepMax, 0.001)
+				assert.Equal(t, 1, r.Points[1].Sessions)
+			},
+		},
+		{
+			name:        "weekly",
+			formula:     "brzycki",
+			granularity: "week",
+			assert: func(t *testing.T, r *domain.ProgressReport) {
+				require.Len(t, r.Points, 1)
+				assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), r.Points[0].Start)
+				assert.Empty(t, r.Points[0].SessionID)
+				assert.Equal(t, 2, r.Points[0].Sessions)
+				assert.Equal(t, 925.0, r.Points[0].Volume)
+				assert.Equal(t, 112.5, r.Points[0].OneRepMax)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, repo, userRepo, exerciseRepo, _ := newReportUsecase()
+			if tt.expectedErr != domain.ErrInvalidInput {
+				if tt.exerciseErr != nil {
+					exerciseRepo.On("GetByID", mock.Anything, "e1").Return(nil, tt.exerciseErr).Once()
+				} else {
+					exerciseRepo.On("GetByID", mock.Anything, "e1").Return(&domain.Exercise{ID: "e1", Name: "Squat"}, nil).Once()
+					userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+					repo.On("GetPerformedSets", mock.Anything, "u1", "e1", (*time.Time)(nil), (*time.Time)(nil)).Return(sets, nil).Once()
+				}
+			}
+
+			report, err := uc.Progress(context.Background(), "u1", "e1", nil, nil, tt.formula, tt.granularity)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+				tt.assert(t, report)
+			}
+			repo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			exerciseRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReportUsecase_Balance(t *testing.T) {
+	t.Parallel()
+
+	uc, repo, userRepo, exerciseRepo, _ := newReportUsecase()
+	userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+	exerciseRepo.On("GetAll", mock.Anything).Return([]domain.Exercise{
+		{ID: "e1", Category: "strength", MuscleGroup: "chest"},
+		{ID: "e2", Category: "strength", MuscleGroup: "back"},
+		{ID: "e3", Category: "strength", MuscleGroup: "legs"},
+		{ID: "e4", Category: "cardio", MuscleGroup: "legs"},
+		{ID: "e5", Category: "strength", MuscleGroup: "core"},
+	}, nil).Once()
+
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	repo.On("GetMuscleWork", mock.Anything, "u1", "UTC", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]domain.MuscleWork{
+		{Day: monday, Category: "strength", MuscleGroup: "chest", TrainingLoad: domain.TrainingLoad{Sets: 9, Volume: 4500}},
+		{Day: monday, Category: "strength", MuscleGroup: "back", TrainingLoad: domain.TrainingLoad{Sets: 3, Volume: 1200}},
+		{Day: monday.AddDate(0, 0, 8), Category: "strength", MuscleGroup: "legs", TrainingLoad: domain.TrainingLoad{Sets: 6, Volume: 6000}},
+	}, nil).Once()
+
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	report, err := uc.Balance(context.Background(), "u1", &from, &to)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.TrainingLoad{Sets: 18, Volume: 11700}, report.Categories["strength"])
+	assert.Equal(t, 9, report.MuscleGroups["chest"].Sets)
+	require.Len(t, report.Weeks, 2)
+	assert.Equal(t, 3, report.Weeks[0].MuscleGroups["back"].Sets)
+	assert.Equal(t, 6, report.Weeks[1].MuscleGroups["legs"].Sets)
+	assert.Empty(t, report.Weeks[1].MuscleGroups["chest"])
+
+	require.Len(t, report.Ratios, len(domain.BalanceRules))
+	assert.Equal(t, "push_pull", report.Ratios[0].Rule.Name)
+	assert.True(t, report.Ratios[0].Imbalanced)
+	assert.Equal(t, 3.0, *report.Ratios[0].Ratio)
+	assert.Equal(t, []string{"core"}, report.Neglected)
+	repo.AssertExpectations(t)
+	userRepo.AssertExpectations(t)
+	exerciseRepo.AssertExpectations(t)
+}
+
+func TestReportUsecase_Adherence(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid granularity", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, _, _, _ := newReportUsecase()
+		_, err := uc.Adherence(context.Background(), "u1", nil, nil, "day")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("per week", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, userRepo, _, seriesRepo := newReportUsecase()
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+
+		// 2026-03-04 is a Wednesday, widened to the weeks of 2 and 9 March.
+		first := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+		last := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+		day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+		repo.On("GetScheduleStatuses", mock.Anything, "u1", first, last).Return([]domain.ScheduledWorkout{
+			{ScheduledDate: day(2), Status: domain.ScheduleStatusCompleted},
+			{ScheduledDate: day(4), Status: domain.ScheduleStatusMissed},
+			{ScheduledDate: day(5), Status: domain.ScheduleStatusCanceled},
+			{ScheduledDate: day(10), Status: domain.ScheduleStatusCompleted},
+		}, nil).Once()
+		repo.On("GetDailyStats", mock.Anything, "u1", "UTC", first, last).Return([]domain.DailyStats{
+			{Day: day(2), Sessions: 1},
+			{Day: day(10), Sessions: 1},
+			{Day: day(11), Sessions: 1},
+		}, nil).Once()
+		repo.On("GetTrainingDays", mock.Anything, "u1", "UTC").Return([]time.Time{day(2), day(10), day(11)}, nil).Once()
+
+		from := day(4)
+		to := day(12)
+		report, err := uc.Adherence(context.Background(), "u1", &from, &to, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.CalendarWeek, report.Granularity)
+		assert.Equal(t, first, report.From)
+		assert.Equal(t, last, report.To)
+		require.Len(t, report.Periods, 2)
+		assert.Equal(t, 2, report.Periods[0].Scheduled)
+		assert.Equal(t, 50.0, *report.Periods[0].Rate())
+		assert.Equal(t, 2, report.Periods[1].Sessions)
+		assert.Equal(t, 100.0, *report.Periods[1].Rate())
+		assert.Equal(t, 3, report.Totals.Scheduled)
+		assert.Equal(t, 3, report.Totals.Sessions)
+		assert.Equal(t, 2, report.DailyStreak.Longest)
+		assert.Equal(t, 2, report.WeeklyStreak.Longest)
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("future range does not materialize past today", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, userRepo, _, seriesRepo := newReportUsecase()
+		today := domain.CivilDate(time.Now().UTC())
+		horizon := today.AddDate(0, 0, 90)
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{{
+			ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: today, Timezone: "UTC", AllDay: true, RRule: "FREQ=DAILY", MaterializedUntil: &horizon,
+		}}, nil).Once()
+		repo.On("GetScheduleStatuses", mock.Anything, "u1", mock.Anything, mock.Anything).Return([]domain.ScheduledWorkout{}, nil).Once()
+		repo.On("GetDailyStats", mock.Anything, "u1", "UTC", mock.Anything, mock.Anything).Return([]domain.DailyStats{}, nil).Once()
+		repo.On("GetTrainingDays", mock.Anything, "u1", "UTC").Return([]time.Time{}, nil).Once()
+
+		from, to := today.AddDate(0, 0, 200), today.AddDate(0, 0, 300)
+		_, err := uc.Adherence(context.Background(), "u1", &from, &to, domain.CalendarMonth)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+		seriesRepo.AssertNotCalled(t, "AddOccurrences", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportUsecase_SessionHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bounded range", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, userRepo, _, _ := newReportUsecase()
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "Asia/Jakarta"}, nil).Once()
+
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		require.NoError(t, err)
+		start := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+		end := time.Date(2026, 4, 1, 0, 0, 0, 0, loc)
+		repo.On("GetSessionLog", mock.Anything, "u1",
+			mock.MatchedBy(func(t *time.Time) bool { return t != nil && t.Equal(start) }),
+			mock.MatchedBy(func(t *time.Time) bool { return t != nil && t.Equal(end) }),
+		).Return([]domain.SessionLogEntry{{SessionID: "s1", ExerciseName: "Squat", SetNumber: 1, Reps: 5, Weight: 100}}, nil).Once()
+
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+		history, err := uc.SessionHistory(context.Background(), "u1", &from, &to)
+		require.NoError(t, err)
+		assert.Equal(t, "Asia/Jakarta", history.Timezone)
+		assert.Equal(t, from, *history.From)
+		assert.Len(t, history.Entries, 1)
+		repo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("range too long", func(t *testing.T) {
+		t.Parallel()
+
+		uc, repo, userRepo, _, _ := newReportUsecase()
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "UTC"}, nil).Once()
+
+		from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+		_, err := uc.SessionHistory(context.Background(), "u1", &from, &to)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+	})
+}
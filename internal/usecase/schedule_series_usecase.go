@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/platform/rrule"
+)
+
+const (
+	seriesHorizonDays    = 90
+	maxSeriesHorizonDays = 366
+	seriesBatchSize      = 100
+)
+
+// ScheduleSeries creates a recurring schedule starting at in.ScheduledAt.
+func (u *ScheduledWorkoutUsecase) ScheduleSeries(ctx context.Context, userID string, in domain.ScheduleInput) (*domain.ScheduleSeries, error) {
+	rule, err := rrule.Parse(in.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("schedule series: %w", domain.ErrInvalidInput)
+	}
+
+	loc, startsAt, err := u.prepareSchedule(ctx, userID, &in)
+	if err != nil {
+		return nil, fmt.Errorf("schedule series: %w", err)
+	}
+	if _, ok := rule.First(startsAt); !ok {
+		return nil, fmt.Errorf("schedule series: %w", domain.ErrInvalidInput)
+	}
+
+	s := &domain.ScheduleSeries{
+		UserID:        userID,
+		WorkoutPlanID: in.WorkoutPlanID,
+		StartsAt:      startsAt,
+		Timezone:      loc.String(),
+		AllDay:        in.AllDay,
+		RRule:         rule.String(),
+		ExDates:       civilDates(in.ExDates),
+	}
+
+	if err := u.seriesRepo.Create(ctx, s); err != nil {
+		return nil, fmt.Errorf("schedule series: %w", err)
+	}
+
+	if err := u.materializeSeries(ctx, s, seriesHorizon(loc)); err != nil {
+		return nil, fmt.Errorf("schedule series: %w", err)
+	}
+
+	return s, nil
+}
+
+func (u *ScheduledWorkoutUsecase) GetSeries(ctx context.Context, id, userID string) (*domain.ScheduleSeries, error) {
+	s, err := u.findSeries(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get series: %w", err)
+	}
+	return s, nil
+}
+
+// UpdateSeries regenerates the pending, non-detached occurrences from today on.
+func (u *ScheduledWorkoutUsecase) UpdateSeries(ctx context.Context, id, userID string, change domain.ScheduleSeriesChange) (*domain.ScheduleSeries, error) {
+	s, err := u.findSeries(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("update series: %w", err)
+	}
+
+	if change.WorkoutPlanID != nil {
+		planID := strings.TrimSpace(*change.WorkoutPlanID)
+		if planID == "" {
+			return nil, fmt.Errorf("update series: %w", domain.ErrInvalidInput)
+		}
+		if planID != s.WorkoutPlanID {
+			if err := u.checkPlanOwner(ctx, userID, planID); err != nil {
+				return nil, fmt.Errorf("update series: %w", err)
+			}
+		}
+		s.WorkoutPlanID = planID
+	}
+
+	loc := s.Location()
+	if change.Timezone != nil {
+		next, err := domain.LoadTimezone(*change.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("update series: %w", err)
+		}
+		s.StartsAt = rebaseTime(s.StartsAt, loc, next)
+		s.Timezone = next.String()
+		loc = next
+	}
+	if change.ScheduledAt != nil {
+		s.StartsAt = scheduleTime(*change.ScheduledAt, change.AllDay, loc)
+		s.AllDay = change.AllDay
+	}
+	if change.ExDates != nil {
+		s.ExDates = civilDates(*change.ExDates)
+	}
+
+	ruleText := s.RRule
+	if change.RRule != nil {
+		ruleText = *change.RRule
+	}
+	rule, err := rrule.Parse(ruleText)
+	if err != nil {
+		return nil, fmt.Errorf("update series: %w", domain.ErrInvalidInput)
+	}
+	if _, ok := rule.First(s.StartsAt.In(loc)); !ok {
+		return nil, fmt.Errorf("update series: %w", domain.ErrInvalidInput)
+	}
+	s.RRule = rule.String()
+
+	from := domain.CivilDate(time.Now().In(loc))
+	if err := u.seriesRepo.Update(ctx, s, from); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("update series: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("update series: %w", err)
+	}
+
+	if err := u.materializeSeries(ctx, s, seriesHorizon(loc)); err != nil {
+		return nil, fmt.Errorf("update series: %w", err)
+	}
+
+	return s, nil
+}
+
+// DeleteSeries removes the series and its pending occurrences from today on.
+func (u *ScheduledWorkoutUsecase) DeleteSeries(ctx context.Context, id, userID string) error {
+	s, err := u.findSeries(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete series: %w", err)
+	}
+
+	from := domain.CivilDate(time.Now().In(s.Location()))
+	if err := u.seriesRepo.Delete(ctx, s.ID, userID, from); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("delete series: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("delete series: %w", err)
+	}
+
+	return nil
+}
+
+// EditOccurrence changes and detaches the occurrence originally on date.
+func (u *ScheduledWorkoutUsecase) EditOccurrence(ctx context.Context, seriesID, userID string, date time.Time, change domain.ScheduleChange) (*domain.ScheduledWorkout, error) {
+	s, err := u.findSeries(ctx, seriesID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("edit occurrence: %w", err)
+	}
+
+	date = domain.CivilDate(date)
+	if date.After(maxSeriesHorizon(s.Location())) {
+		return nil, fmt.Errorf("edit occurrence: %w", domain.ErrInvalidInput)
+	}
+	if err := u.materializeSeries(ctx, s, date); err != nil {
+		return nil, fmt.Errorf("edit occurrence: %w", err)
+	}
+
+	sw, err := u.repo.GetOccurrence(ctx, s.ID, userID, date)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("edit occurrence: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("edit occurrence: %w", err)
+	}
+
+	sw.Detached = true
+	if err := u.applyChange(ctx, userID, sw, change); err != nil {
+		return nil, fmt.Errorf("edit occurrence: %w", err)
+	}
+
+	return sw, nil
+}
+
+func (u *ScheduledWorkoutUsecase) findSeries(ctx context.Context, id, userID string) (*domain.ScheduleSeries, error) {
+	if userID == "" || strings.TrimSpace(id) == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	s, err := u.seriesRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// MaterializeSeries stores the user's occurrences up to through, capped at
+// the maximum horizon.
+func (u *ScheduledWorkoutUsecase) MaterializeSeries(ctx context.Context, userID string, through *time.Time) error {
+	if userID == "" {
+		return fmt.Errorf("materialize series: %w", domain.ErrInvalidInput)
+	}
+	if err := u.materialize(ctx, userID, through); err != nil {
+		return fmt.Errorf("materialize series: %w", err)
+	}
+	return nil
+}
+
+// MaterializeStale extends the series of users whose occurrences end before
+// the default horizon.
+func (u *ScheduledWorkoutUsecase) MaterializeStale(ctx context.Context, now time.Time) (int, error) {
+	// Local dates are at most a day behind UTC.
+	before := domain.CivilDate(now.UTC()).AddDate(0, 0, seriesHorizonDays-1)
+	userIDs, err := u.seriesRepo.GetStaleUserIDs(ctx, before, seriesBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("materialize stale series: %w", err)
+	}
+
+	done := 0
+	var errs []error
+	for _, userID := range userIDs {
+		if err := u.MaterializeSeries(ctx, userID, nil); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		done++
+	}
+
+	if len(errs) > 0 {
+		return done, fmt.Errorf("materialize stale series: %w", errors.Join(errs...))
+	}
+	return done, nil
+}
+
+func (u *ScheduledWorkoutUsecase) materialize(ctx context.Context, userID string, through *time.Time) error {
+	series, err := u.seriesRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for i := range series {
+		s := &series[i]
+		until := seriesHorizon(s.Location())
+		if through != nil && through.After(until) {
+			until = domain.CivilDate(*through)
+		}
+		if limit := maxSeriesHorizon(s.Location()); until.After(limit) {
+			until = limit
+		}
+		if err := u.materializeSeries(ctx, s, until); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u *ScheduledWorkoutUsecase) materializeSeries(ctx context.Context, s *domain.ScheduleSeries, through time.Time) error {
+	if s.MaterializedUntil != nil && !s.MaterializedUntil.Before(through) {
+		return nil
+	}
+
+	rule, err := rrule.Parse(s.RRule)
+	if err != nil {
+		return err
+	}
+
+	loc := s.Location()
+	start := s.StartsAt.In(loc)
+	from := start
+	if s.MaterializedUntil != nil {
+		next := s.MaterializedUntil.AddDate(0, 0, 1)
+		from = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, loc)
+	}
+	to := time.Date(through.Year(), through.Month(), through.Day()+1, 0, 0, 0, 0, loc)
+
+	var occurrences []domain.ScheduledWorkout
+	for _, at := range rule.Between(start, from, to) {
+		date := domain.CivilDate(at)
+		if s.IsExcluded(date) {
+			continue
+		}
+		occurrences = append(occurrences, domain.ScheduledWorkout{
+			UserID:         s.UserID,
+			WorkoutPlanID:  s.WorkoutPlanID,
+			ScheduledDate:  date,
+			ScheduledAt:    at,
+			Timezone:       s.Timezone,
+			AllDay:         s.AllDay,
+			SeriesID:       s.ID,
+			OccurrenceDate: &date,
+		})
+	}
+
+	if err := u.seriesRepo.AddOccurrences(ctx, s.ID, occurrences, through); err != nil {
+		return err
+	}
+
+	s.MaterializedUntil = &through
+	return nil
+}
+
+func seriesHorizon(loc *time.Location) time.Time {
+	return domain.CivilDate(time.Now().In(loc)).AddDate(0, 0, seriesHorizonDays)
+}
+
+func maxSeriesHorizon(loc *time.Location) time.Time {
+	return domain.CivilDate(time.Now().In(loc)).AddDate(0, 0, maxSeriesHorizonDays)
+}
+
+// withinSeriesLimit measures from the latest local date in any timezone.
+func withinSeriesLimit(through *time.Time) bool {
+	if through == nil {
+		return true
+	}
+	latest := domain.CivilDate(time.Now().UTC()).AddDate(0, 0, maxSeriesHorizonDays+1)
+	return !domain.CivilDate(*through).After(latest)
+}
+
+func civilDates(in []time.Time) []time.Time {
+	out := make([]time.Time, 0, len(in))
+	for _, d := range in {
+		out = append(out, domain.CivilDate(d))
+	}
+	return out
+}
@@ -0,0 +1,333 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+type seriesMocks struct {
+	repo       *mocks.MockScheduledWorkoutRepository
+	checker    *mocks.MockWorkoutPlanChecker
+	seriesRepo *mocks.MockScheduleSeriesRepository
+}
+
+func newSeriesUsecase() (*usecase.ScheduledWorkoutUsecase, seriesMocks) {
+	m := seriesMocks{
+		repo:       new(mocks.MockScheduledWorkoutRepository),
+		checker:    new(mocks.MockWorkoutPlanChecker),
+		seriesRepo: new(mocks.MockScheduleSeriesRepository),
+	}
+	uc := usecase.NewScheduledWorkoutUsecase(m.repo, m.checker, new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), m.seriesRepo)
+	return uc, m
+}
+
+func (m seriesMocks) assert(t *testing.T) {
+	m.repo.AssertExpectations(t)
+	m.checker.AssertExpectations(t)
+	m.seriesRepo.AssertExpectations(t)
+}
+
+func TestScheduledWorkoutUsecase_ScheduleSeries(t *testing.T) {
+	t.Parallel()
+
+	today := time.Now().UTC()
+	tomorrow := time.Date(today.Year(), today.Month(), today.Day()+1, 7, 0, 0, 0, time.UTC)
+
+	t.Run("materializes occurrences without exdates", func(t *testing.T) {
+		t.Parallel()
+
+		uc, m := newSeriesUsecase()
+		m.checker.On("GetOwnerID", mock.Anything, "p1").Return("u1", nil).Once()
+		m.seriesRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.ScheduleSeries")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.ScheduleSeries).ID = "series-1"
+		}).Once()
+
+		var stored []domain.ScheduledWorkout
+		m.seriesRepo.On("AddOccurrences", mock.Anything, "series-1", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			stored = args.Get(2).([]domain.ScheduledWorkout)
+		}).Once()
+
+		s, err := uc.ScheduleSeries(context.Background(), "u1", domain.ScheduleInput{
+			WorkoutPlanID: "p1",
+			ScheduledAt:   tomorrow,
+			Timezone:      "UTC",
+			RRule:         "freq=daily;count=3",
+			ExDates:       []time.Time{tomorrow.AddDate(0, 0, 1)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=DAILY;COUNT=3", s.RRule)
+
+		require.Len(t, stored, 2)
+		assert.Equal(t, domain.CivilDate(tomorrow), stored[0].ScheduledDate)
+		assert.Equal(t, domain.CivilDate(tomorrow.AddDate(0, 0, 2)), stored[1].ScheduledDate)
+		assert.Equal(t, "series-1", stored[1].SeriesID)
+		assert.Equal(t, 7, stored[1].ScheduledAt.Hour())
+		m.assert(t)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		t.Parallel()
+
+		uc, m := newSeriesUsecase()
+		_, err := uc.ScheduleSeries(context.Background(), "u1", domain.ScheduleInput{
+			WorkoutPlanID: "p1",
+			ScheduledAt:   tomorrow,
+			Timezone:      "UTC",
+			RRule:         "FREQ=HOURLY",
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		m.assert(t)
+	})
+
+	t.Run("rule without occurrences", func(t *testing.T) {
+		t.Parallel()
+
+		uc, m := newSeriesUsecase()
+		m.checker.On("GetOwnerID", mock.Anything, "p1").Return("u1", nil).Once()
+		_, err := uc.ScheduleSeries(context.Background(), "u1", domain.ScheduleInput{
+			WorkoutPlanID: "p1",
+			ScheduledAt:   tomorrow,
+			Timezone:      "UTC",
+			RRule:         "FREQ=DAILY;UNTIL=20000101",
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		m.assert(t)
+	})
+}
+
+func TestScheduledWorkoutUsecase_UpdateSeries(t *testing.T) {
+	t.Parallel()
+
+	today := domain.CivilDate(time.Now().UTC())
+	lastWeek := today.AddDate(0, 0, -7).Add(18 * time.Hour)
+	horizon := today.AddDate(0, 0, 90)
+
+	t.Run("regenerates from today", func(t *testing.T) {
+		t.Parallel()
+
+		uc, m := newSeriesUsecase()
+		m.seriesRepo.On("GetByID", mock.Anything, "series-1", "u1").Return(&domain.ScheduleSeries{
+			ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: lastWeek, Timezone: "UTC",
+			RRule: "FREQ=DAILY", MaterializedUntil: &horizon,
+		}, nil).Once()
+		m.seriesRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.ScheduleSeries"), today).Return(nil).Run(func(args mock.Arguments) {
+			prev := today.AddDate(0, 0, -1)
+			args.Get(1).(*domain.ScheduleSeries).MaterializedUntil = &prev
+		}).Once()
+
+		var stored []domain.ScheduledWorkout
+		m.seriesRepo.On("AddOccurrences", mock.Anything, "series-1", mock.Anything, horizon).Return(nil).Run(func(args mock.Arguments) {
+			stored = args.Get(2).([]domain.ScheduledWorkout)
+		}).Once()
+
+		rule := "FREQ=WEEKLY"
+		s, err := uc.UpdateSeries(context.Background(), "series-1", "u1", domain.ScheduleSeriesChange{RRule: &rule})
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY", s.RRule)
+
+		require.NotEmpty(t, stored)
+		for _, sw := range stored {
+			assert.False(t, sw.ScheduledDate.Before(today))
+			assert.Equal(t, lastWeek.Weekday(), sw.ScheduledAt.Weekday())
+		}
+		m.assert(t)
+	})
+
+	t.Run("plan owned by someone else", func(t *testing.T) {
+		t.Parallel()
+
+		uc, m := newSeriesUsecase()
+		m.seriesRepo.On("GetByID", mock.Anything, "series-1", "u1").Return(&domain.ScheduleSeries{
+			ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: lastWeek, Timezone: "UTC", RRule: "FREQ=DAILY",
+		}, nil).Once()
+		m.checker.On("GetOwnerID", mock.Anything, "p2").Return("u2", nil).Once()
+
+		plan := "p2"
+		_, err := uc.UpdateSeries(context.Background(), "series-1", "u1", domain.ScheduleSeriesChange{
+			ScheduleChange: domain.ScheduleChange{WorkoutPlanID: &plan},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrForbidden))
+		m.assert(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		uc, m := newSeriesUsecase()
+		m.seriesRepo.On("GetByID", mock.Anything, "series-1", "u1").Return(nil, sql.ErrNoRows).Once()
+
+		_, err := uc.UpdateSeries(context.Background(), "series-1", "u1", domain.ScheduleSeriesChange{})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		m.assert(t)
+	})
+}
+
+func TestScheduledWorkoutUsecase_EditOccurrence(t *testing.T) {
+	t.Parallel()
+
+	today := domain.CivilDate(time.Now().UTC())
+	occurrence := today.AddDate(0, 0, 3)
+	horizon := today.AddDate(0, 0, 90)
+	series := func() *domain.ScheduleSeries {
+		return &domain.ScheduleSeries{
+			ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: today.Add(7 * time.Hour),
+			Timezone: "UTC", RRule: "FREQ=DAILY", MaterializedUntil: &horizon,
+		}
+	}
+	row := func(status string) *domain.ScheduledWorkout {
+		return &domain.ScheduledWorkout{
+			ID: "s1", UserID: "u1", WorkoutPlanID: "p1", ScheduledDate: occurrence,
+			ScheduledAt: occurrence.Add(7 * time.Hour), Timezone: "UTC", Status: status,
+			SeriesID: "series-1", OccurrenceDate: &occurrence,
+		}
+	}
+
+	moved := occurrence.AddDate(0, 0, 1).Add(18 * time.Hour)
+
+	tests := []struct {
+		name        string
+		date        time.Time
+		row         *domain.ScheduledWorkout
+		rowErr      error
+		existing    []domain.ScheduledWorkout
+		updateErr   error
+		expectedErr error
+	}{
+		{name: "moves and detaches", row: row(domain.ScheduleStatusPending)},
+		{name: "missing occurrence", rowErr: sql.ErrNoRows, expectedErr: domain.ErrNotFound},
+		{name: "not pending", row: row(domain.ScheduleStatusCompleted), expectedErr: domain.ErrConflict},
+		{
+			name:        "target day already has plan",
+			row:         row(domain.ScheduleStatusPending),
+			existing:    []domain.ScheduledWorkout{{ID: "other", WorkoutPlanID: "p1"}},
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name:        "concurrent duplicate",
+			row:         row(domain.ScheduleStatusPending),
+			updateErr:   sql.ErrNoRows,
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name:        "date past max horizon",
+			date:        today.AddDate(0, 0, 400),
+			expectedErr: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc, m := newSeriesUsecase()
+			m.seriesRepo.On("GetByID", mock.Anything, "series-1", "u1").Return(series(), nil).Once()
+			date := occurrence
+			if !tt.date.IsZero() {
+				date = tt.date
+			}
+			if tt.row != nil || tt.rowErr != nil {
+				m.repo.On("GetOccurrence", mock.Anything, "series-1", "u1", occurrence).Return(tt.row, tt.rowErr).Once()
+			}
+
+			if tt.row != nil && tt.row.Status == domain.ScheduleStatusPending {
+				m.repo.On("GetByUser", mock.Anything, "u1", mock.Anything, mock.Anything).
+					Return(domain.NewPaginatedResult(tt.existing, len(tt.existing), domain.NewPagination(1, 100)), nil).Once()
+				if len(tt.existing) == 0 {
+					m.repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.ScheduledWorkout")).Return(tt.updateErr).Once()
+				}
+			}
+
+			sw, err := uc.EditOccurrence(context.Background(), "series-1", "u1", date, domain.ScheduleChange{ScheduledAt: &moved})
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+				assert.True(t, sw.Detached)
+				assert.Equal(t, domain.CivilDate(moved), sw.ScheduledDate)
+				assert.Equal(t, occurrence, *sw.OccurrenceDate)
+			}
+			m.assert(t)
+		})
+	}
+}
+
+func TestScheduledWorkoutUsecase_DeleteSeries(t *testing.T) {
+	t.Parallel()
+
+	uc, m := newSeriesUsecase()
+	m.seriesRepo.On("GetByID", mock.Anything, "series-1", "u1").Return(&domain.ScheduleSeries{ID: "series-1", UserID: "u1", Timezone: "UTC"}, nil).Once()
+	m.seriesRepo.On("Delete", mock.Anything, "series-1", "u1", domain.CivilDate(time.Now().UTC())).Return(nil).Once()
+	require.NoError(t, uc.DeleteSeries(context.Background(), "series-1", "u1"))
+	m.assert(t)
+
+	uc2, m2 := newSeriesUsecase()
+	m2.seriesRepo.On("GetByID", mock.Anything, "series-1", "u1").Return(nil, sql.ErrNoRows).Once()
+	err := uc2.DeleteSeries(context.Background(), "series-1", "u1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrNotFound))
+	m2.assert(t)
+}
+
+func TestScheduledWorkoutUsecase_MaterializeSeriesCapsHorizon(t *testing.T) {
+	t.Parallel()
+
+	today := domain.CivilDate(time.Now().UTC())
+	far := time.Date(2999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	uc, m := newSeriesUsecase()
+	m.seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{{
+		ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: today.Add(7 * time.Hour), Timezone: "UTC", RRule: "FREQ=DAILY",
+	}}, nil).Once()
+
+	var stored []domain.ScheduledWorkout
+	var through time.Time
+	m.seriesRepo.On("AddOccurrences", mock.Anything, "series-1", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(2).([]domain.ScheduledWorkout)
+		through = args.Get(3).(time.Time)
+	}).Once()
+
+	require.NoError(t, uc.MaterializeSeries(context.Background(), "u1", &far))
+	assert.Equal(t, today.AddDate(0, 0, 366), through)
+	require.Len(t, stored, 367)
+	assert.Equal(t, through, stored[len(stored)-1].ScheduledDate)
+	m.assert(t)
+}
+
+func TestScheduledWorkoutUsecase_MaterializeStale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	today := domain.CivilDate(now.UTC())
+	before := today.AddDate(0, 0, 89)
+	stale := today.AddDate(0, 0, 10)
+
+	uc, m := newSeriesUsecase()
+	m.seriesRepo.On("GetStaleUserIDs", mock.Anything, before, 100).Return([]string{"u1", "u2"}, nil).Once()
+	m.seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{{
+		ID: "series-1", UserID: "u1", WorkoutPlanID: "p1", StartsAt: today, Timezone: "UTC", AllDay: true, RRule: "FREQ=WEEKLY", MaterializedUntil: &stale,
+	}}, nil).Once()
+	m.seriesRepo.On("AddOccurrences", mock.Anything, "series-1", mock.Anything, today.AddDate(0, 0, 90)).Return(nil).Once()
+	m.seriesRepo.On("GetByUser", mock.Anything, "u2").Return(nil, errors.New("connection reset")).Once()
+
+	n, err := uc.MaterializeStale(context.Background(), now)
+	require.Error(t, err)
+	assert.Equal(t, 1, n)
+	m.assert(t)
+}
@@ -5,78 +5,223 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"workout-tracker/internal/domain"
 	"workout-tracker/internal/repository"
 )
 
+const maxCancelReasonLength = 500
+
 type ScheduledWorkoutUsecase struct {
 	repo        repository.ScheduledWorkoutRepository
 	planChecker repository.WorkoutPlanChecker
+	sessionRepo repository.WorkoutSessionRepository
+	userRepo    domain.UserRepository
+	seriesRepo  repository.ScheduleSeriesRepository
 }
 
-func NewScheduledWorkoutUsecase(repo repository.ScheduledWorkoutRepository, planChecker repository.WorkoutPlanChecker) *ScheduledWorkoutUsecase {
-	return &ScheduledWorkoutUsecase{repo: repo, planChecker: planChecker}
+func NewScheduledWorkoutUsecase(repo repository.ScheduledWorkoutRepository, planChecker repository.WorkoutPlanChecker, sessionRepo repository.WorkoutSessionRepository, userRepo domain.UserRepository, seriesRepo repository.ScheduleSeriesRepository) *ScheduledWorkoutUsecase {
+	return &ScheduledWorkoutUsecase{repo: repo, planChecker: planChecker, sessionRepo: sessionRepo, userRepo: userRepo, seriesRepo: seriesRepo}
 }
 
-func (u *ScheduledWorkoutUsecase) ScheduleWorkout(ctx context.Context, userID, workoutPlanID string, scheduledDate time.Time) error {
-	if userID == "" {
-		return fmt.Errorf("schedule workout: %w", domain.ErrInvalidInput)
+// ScheduleWorkout creates a schedule in the requested timezone, falling back
+// to the user's default. "Today" is evaluated in that same timezone.
+func (u *ScheduledWorkoutUsecase) ScheduleWorkout(ctx context.Context, userID string, in domain.ScheduleInput) (*domain.ScheduledWorkout, error) {
+	loc, scheduledAt, err := u.prepareSchedule(ctx, userID, &in)
+	if err != nil {
+		return nil, fmt.Errorf("schedule workout: %w", err)
+	}
+
+	date := domain.CivilDate(scheduledAt)
+	if err := u.checkDuplicate(ctx, userID, in.WorkoutPlanID, date, ""); err != nil {
+		return nil, fmt.Errorf("schedule workout: %w", err)
 	}
-	if workoutPlanID == "" {
-		return fmt.Errorf("schedule workout: %w", domain.ErrInvalidInput)
+
+	sw := &domain.ScheduledWorkout{
+		UserID:        userID,
+		WorkoutPlanID: in.WorkoutPlanID,
+		ScheduledDate: date,
+		ScheduledAt:   scheduledAt,
+		Timezone:      loc.String(),
+		AllDay:        in.AllDay,
 	}
 
-	date := time.Date(scheduledDate.Year(), scheduledDate.Month(), scheduledDate.Day(), 0, 0, 0, 0, time.UTC)
-	today := time.Now().UTC()
-	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
-	if date.Before(today) {
-		return fmt.Errorf("schedule workout: %w", domain.ErrInvalidInput)
+	if err := u.repo.Create(ctx, sw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("schedule workout: %w", domain.ErrConflict)
+		}
+		return nil, fmt.Errorf("schedule workout: %w", err)
+	}
+
+	return sw, nil
+}
+
+// prepareSchedule validates a new schedule and resolves its timezone and
+// start time. It returns bare domain errors for the caller to wrap.
+func (u *ScheduledWorkoutUsecase) prepareSchedule(ctx context.Context, userID string, in *domain.ScheduleInput) (*time.Location, time.Time, error) {
+	in.WorkoutPlanID = strings.TrimSpace(in.WorkoutPlanID)
+	if userID == "" || in.WorkoutPlanID == "" || in.ScheduledAt.IsZero() {
+		return nil, time.Time{}, domain.ErrInvalidInput
+	}
+
+	loc, err := u.resolveTimezone(ctx, userID, in.Timezone)
+	if err != nil {
+		return nil, time.Time{}, err
 	}
 
+	scheduledAt := scheduleTime(in.ScheduledAt, in.AllDay, loc)
+	if domain.CivilDate(scheduledAt).Before(domain.CivilDate(time.Now().In(loc))) {
+		return nil, time.Time{}, domain.ErrInvalidInput
+	}
+
+	if err := u.checkPlanOwner(ctx, userID, in.WorkoutPlanID); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return loc, scheduledAt, nil
+}
+
+func (u *ScheduledWorkoutUsecase) checkPlanOwner(ctx context.Context, userID, workoutPlanID string) error {
 	ownerID, err := u.planChecker.GetOwnerID(ctx, workoutPlanID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("schedule workout: %w", domain.ErrNotFound)
+			return domain.ErrNotFound
 		}
-		return fmt.Errorf("schedule workout: %w", err)
+		return err
 	}
 	if ownerID != userID {
-		return fmt.Errorf("schedule workout: %w", domain.ErrForbidden)
+		return domain.ErrForbidden
 	}
+	return nil
+}
 
+// checkDuplicate reports ErrConflict when the plan is already scheduled on
+// date, ignoring the schedule identified by exceptID.
+func (u *ScheduledWorkoutUsecase) checkDuplicate(ctx context.Context, userID, workoutPlanID string, date time.Time, exceptID string) error {
 	res, err := u.repo.GetByUser(ctx, userID, domain.NewPagination(1, 100), domain.ScheduledWorkoutFilter{Date: &date})
 	if err != nil {
-		return fmt.Errorf("schedule workout: %w", err)
+		return err
 	}
 	for _, sw := range res.Data {
-		if sw.WorkoutPlanID == workoutPlanID {
-			return fmt.Errorf("schedule workout: %w", domain.ErrConflict)
+		if sw.WorkoutPlanID == workoutPlanID && (exceptID == "" || sw.ID != exceptID) {
+			return domain.ErrConflict
 		}
 	}
+	return nil
+}
+
+// applyChange edits the plan and timing of a pending schedule and saves it.
+// A timezone change keeps the local wall-clock time.
+func (u *ScheduledWorkoutUsecase) applyChange(ctx context.Context, userID string, sw *domain.ScheduledWorkout, change domain.ScheduleChange) error {
+	if sw.Status != domain.ScheduleStatusPending {
+		return domain.ErrConflict
+	}
 
-	sw := &domain.ScheduledWorkout{
-		UserID:        userID,
-		WorkoutPlanID: workoutPlanID,
-		ScheduledDate: date,
+	if change.WorkoutPlanID != nil {
+		planID := strings.TrimSpace(*change.WorkoutPlanID)
+		if planID == "" {
+			return domain.ErrInvalidInput
+		}
+		if planID != sw.WorkoutPlanID {
+			if err := u.checkPlanOwner(ctx, userID, planID); err != nil {
+				return err
+			}
+		}
+		sw.WorkoutPlanID = planID
 	}
 
-	if err := u.repo.Create(ctx, sw); err != nil {
+	loc := sw.Location()
+	if change.Timezone != nil {
+		next, err := domain.LoadTimezone(*change.Timezone)
+		if err != nil {
+			return err
+		}
+		sw.ScheduledAt = rebaseTime(sw.ScheduledAt, loc, next)
+		sw.Timezone = next.String()
+		loc = next
+	}
+	if change.ScheduledAt != nil {
+		sw.ScheduledAt = scheduleTime(*change.ScheduledAt, change.AllDay, loc)
+		sw.AllDay = change.AllDay
+	}
+
+	date := domain.CivilDate(sw.ScheduledAt.In(loc))
+	if (change.ScheduledAt != nil || change.Timezone != nil) && date.Before(domain.CivilDate(time.Now().In(loc))) {
+		return domain.ErrInvalidInput
+	}
+	sw.ScheduledDate = date
+
+	if err := u.checkDuplicate(ctx, userID, sw.WorkoutPlanID, date, sw.ID); err != nil {
+		return err
+	}
+
+	if err := u.repo.Update(ctx, sw); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("schedule workout: %w", domain.ErrConflict)
+			return domain.ErrConflict
 		}
-		return fmt.Errorf("schedule workout: %w", err)
+		return err
 	}
 
 	return nil
 }
 
+// scheduleTime expresses t in loc. For all-day schedules only the calendar
+// day of t is kept, at local midnight.
+func scheduleTime(t time.Time, allDay bool, loc *time.Location) time.Time {
+	if allDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+	return t.In(loc)
+}
+
+func rebaseTime(t time.Time, from, to *time.Location) time.Time {
+	l := t.In(from)
+	return time.Date(l.Year(), l.Month(), l.Day(), l.Hour(), l.Minute(), l.Second(), l.Nanosecond(), to)
+}
+
+func (u *ScheduledWorkoutUsecase) resolveTimezone(ctx context.Context, userID, name string) (*time.Location, error) {
+	if strings.TrimSpace(name) == "" {
+		return userLocation(ctx, u.userRepo, userID)
+	}
+
+	return domain.LoadTimezone(name)
+}
+
+// userLocation loads the user's default timezone.
+func userLocation(ctx context.Context, userRepo domain.UserRepository, userID string) (*time.Location, error) {
+	user, err := userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	name := user.Timezone
+	if name == "" {
+		name = domain.DefaultTimezone
+	}
+	return domain.LoadTimezone(name)
+}
+
 func (u *ScheduledWorkoutUsecase) GetSchedules(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.ScheduledWorkout], error) {
 	if userID == "" {
 		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules: %w", domain.ErrInvalidInput)
 	}
 
+	if err := normalizeScheduleFilter(&filters); err != nil {
+		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules: %w", err)
+	}
+	if !withinSeriesLimit(filterHorizon(filters)) {
+		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules: %w", domain.ErrInvalidInput)
+	}
+
+	if err := u.materialize(ctx, userID, filterHorizon(filters)); err != nil {
+		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules: %w", err)
+	}
+
 	res, err := u.repo.GetByUser(ctx, userID, pagination, filters)
 	if err != nil {
 		return domain.PaginatedResult[domain.ScheduledWorkout]{}, fmt.Errorf("get schedules: %w", err)
@@ -84,6 +229,186 @@ func (u *ScheduledWorkoutUsecase) GetSchedules(ctx context.Context, userID strin
 	return res, nil
 }
 
+// GetAgenda lists upcoming schedules with their plan names, soonest first.
+// Without a range it starts at today in the user's timezone, and without a
+// status it only shows pending schedules.
+func (u *ScheduledWorkoutUsecase) GetAgenda(ctx context.Context, userID string, pagination domain.Pagination, filters domain.ScheduledWorkoutFilter) (domain.PaginatedResult[domain.AgendaItem], error) {
+	if userID == "" {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", domain.ErrInvalidInput)
+	}
+
+	if filters.From == nil && filters.Date == nil {
+		loc, err := u.resolveTimezone(ctx, userID, "")
+		if err != nil {
+			return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+		}
+		today := domain.CivilDate(time.Now().In(loc))
+		filters.From = &today
+	}
+	if strings.TrimSpace(filters.Status) == "" {
+		filters.Status = domain.ScheduleStatusPending
+	}
+	if strings.TrimSpace(filters.Sort) == "" {
+		filters.Sort = domain.SortAsc
+	}
+
+	if err := normalizeScheduleFilter(&filters); err != nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+	}
+	if !withinSeriesLimit(filterHorizon(filters)) {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", domain.ErrInvalidInput)
+	}
+
+	if err := u.materialize(ctx, userID, filterHorizon(filters)); err != nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+	}
+
+	res, err := u.repo.GetAgenda(ctx, userID, pagination, filters)
+	if err != nil {
+		return domain.PaginatedResult[domain.AgendaItem]{}, fmt.Errorf("get agenda: %w", err)
+	}
+	return res, nil
+}
+
+func normalizeScheduleFilter(filters *domain.ScheduledWorkoutFilter) error {
+	filters.Status = strings.TrimSpace(filters.Status)
+	if filters.Status != "" && !domain.IsValidScheduleStatus(filters.Status) {
+		return domain.ErrInvalidInput
+	}
+
+	filters.Sort = strings.ToLower(strings.TrimSpace(filters.Sort))
+	if filters.Sort != "" && filters.Sort != domain.SortAsc && filters.Sort != domain.SortDesc {
+		return domain.ErrInvalidInput
+	}
+
+	if filters.From != nil && filters.To != nil && filters.To.Before(*filters.From) {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
+
+// filterHorizon is the last day a listing can show, used to decide how far
+// series must be materialized. Nil means the default horizon. Listings that
+// reach past the maximum horizon are rejected rather than left incomplete.
+func filterHorizon(filters domain.ScheduledWorkoutFilter) *time.Time {
+	if filters.Date != nil {
+		return filters.Date
+	}
+	return filters.To
+}
+
+// CompleteSchedule marks a schedule as done. When sessionID is set, the
+// session must belong to the user and already be finished.
+func (u *ScheduledWorkoutUsecase) CompleteSchedule(ctx context.Context, id, userID, sessionID string) (*domain.ScheduledWorkout, error) {
+	sessionID = strings.TrimSpace(sessionID)
+	if sessionID != "" {
+		s, err := u.sessionRepo.GetByID(ctx, sessionID, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("complete schedule: %w", domain.ErrNotFound)
+			}
+			return nil, fmt.Errorf("complete schedule: %w", err)
+		}
+		if !s.IsFinished() {
+			return nil, fmt.Errorf("complete schedule: %w", domain.ErrConflict)
+		}
+	}
+
+	return u.transition(ctx, "complete schedule", id, userID, domain.ScheduleStatusCompleted, func(sw *domain.ScheduledWorkout) {
+		sw.WorkoutSessionID = sessionID
+		sw.CancelReason = ""
+	})
+}
+
+func (u *ScheduledWorkoutUsecase) CancelSchedule(ctx context.Context, id, userID, reason string) (*domain.ScheduledWorkout, error) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > maxCancelReasonLength {
+		return nil, fmt.Errorf("cancel schedule: %w", domain.ErrInvalidInput)
+	}
+
+	return u.transition(ctx, "cancel schedule", id, userID, domain.ScheduleStatusCanceled, func(sw *domain.ScheduledWorkout) {
+		sw.WorkoutSessionID = ""
+		sw.CancelReason = reason
+	})
+}
+
+func (u *ScheduledWorkoutUsecase) ReopenSchedule(ctx context.Context, id, userID string) (*domain.ScheduledWorkout, error) {
+	return u.transition(ctx, "reopen schedule", id, userID, domain.ScheduleStatusPending, func(sw *domain.ScheduledWorkout) {
+		sw.WorkoutSessionID = ""
+		sw.CancelReason = ""
+	})
+}
+
+func (u *ScheduledWorkoutUsecase) transition(ctx context.Context, op, id, userID, to string, apply func(*domain.ScheduledWorkout)) (*domain.ScheduledWorkout, error) {
+	if userID == "" || strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrInvalidInput)
+	}
+
+	sw, err := u.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !domain.CanTransitionSchedule(sw.Status, to) {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrConflict)
+	}
+
+	sw.Status = to
+	apply(sw)
+
+	if err := u.repo.UpdateStatus(ctx, sw); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sw, nil
+}
+
+// RescheduleWorkout moves a pending schedule to another time and/or plan,
+// keeping its ID. A moved series occurrence is detached from its series.
+func (u *ScheduledWorkoutUsecase) RescheduleWorkout(ctx context.Context, id, userID string, change domain.ScheduleChange) (*domain.ScheduledWorkout, error) {
+	if userID == "" || strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("reschedule workout: %w", domain.ErrInvalidInput)
+	}
+
+	sw, err := u.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("reschedule workout: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("reschedule workout: %w", err)
+	}
+
+	if sw.SeriesID != "" {
+		sw.Detached = true
+	}
+	if err := u.applyChange(ctx, userID, sw, change); err != nil {
+		return nil, fmt.Errorf("reschedule workout: %w", err)
+	}
+
+	return sw, nil
+}
+
+// MarkMissed moves pending schedules to missed once they ended more than
+// grace ago, so adherence is not skewed by schedules nobody closed. It is
+// safe to run from several instances at once.
+func (u *ScheduledWorkoutUsecase) MarkMissed(ctx context.Context, now time.Time, grace time.Duration) (int, error) {
+	if grace < 0 {
+		return 0, fmt.Errorf("mark missed: %w", domain.ErrInvalidInput)
+	}
+
+	n, err := u.repo.MarkMissed(ctx, now, grace)
+	if err != nil {
+		return 0, fmt.Errorf("mark missed: %w", err)
+	}
+	return n, nil
+}
+
 func (u *ScheduledWorkoutUsecase) DeleteSchedule(ctx context.Context, id, userID string) error {
 	if userID == "" {
 		return fmt.Errorf("delete schedule: %w", domain.ErrInvalidInput)
@@ -23,49 +23,91 @@ func TestScheduledWorkoutUsecase_ScheduleWorkout(t *testing.T) {
 	tomorrow := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
 	yesterday := time.Date(today.Year(), today.Month(), today.Day()-1, 0, 0, 0, 0, time.UTC)
 
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	require.NoError(t, err)
+	localToday := time.Now().In(kiritimati)
+	localYesterday := localToday.AddDate(0, 0, -1)
+
 	tests := []struct {
-		name        string
-		date        time.Time
-		ownerID     string
-		ownerErr    error
-		existing    []domain.ScheduledWorkout
-		existingErr error
-		createErr   error
-		expectedErr error
+		name         string
+		in           domain.ScheduleInput
+		userTimezone string
+		userErr      error
+		ownerID      string
+		ownerErr     error
+		existing     []domain.ScheduledWorkout
+		existingErr  error
+		createErr    error
+		expectedErr  error
+		expectedDate time.Time
+		expectedTZ   string
 	}{
 		{
-			name:    "success",
-			date:    tomorrow,
-			ownerID: "u1",
+			name:         "success",
+			in:           domain.ScheduleInput{ScheduledAt: tomorrow, AllDay: true, Timezone: "UTC"},
+			ownerID:      "u1",
+			expectedDate: tomorrow,
+			expectedTZ:   "UTC",
+		},
+		{
+			name:         "uses user timezone",
+			in:           domain.ScheduleInput{ScheduledAt: localToday, AllDay: true},
+			userTimezone: "Pacific/Kiritimati",
+			ownerID:      "u1",
+			expectedDate: domain.CivilDate(localToday),
+			expectedTZ:   "Pacific/Kiritimati",
+		},
+		{
+			name:         "timed schedule keeps local date",
+			in:           domain.ScheduleInput{ScheduledAt: time.Date(today.Year(), today.Month(), today.Day()+2, 23, 30, 0, 0, time.UTC), Timezone: "Pacific/Kiritimati"},
+			ownerID:      "u1",
+			expectedDate: time.Date(today.Year(), today.Month(), today.Day()+3, 0, 0, 0, 0, time.UTC),
+			expectedTZ:   "Pacific/Kiritimati",
 		},
 		{
 			name:        "past date",
-			date:        yesterday,
-			ownerID:     "u1",
+			in:          domain.ScheduleInput{ScheduledAt: yesterday, AllDay: true, Timezone: "UTC"},
 			expectedErr: domain.ErrInvalidInput,
 		},
+		{
+			name:         "past date in user timezone",
+			in:           domain.ScheduleInput{ScheduledAt: localYesterday, AllDay: true},
+			userTimezone: "Pacific/Kiritimati",
+			expectedErr:  domain.ErrInvalidInput,
+		},
+		{
+			name:        "invalid timezone",
+			in:          domain.ScheduleInput{ScheduledAt: tomorrow, Timezone: "Mars/Olympus"},
+			expectedErr: domain.ErrInvalidInput,
+		},
+		{
+			name:        "user not found",
+			in:          domain.ScheduleInput{ScheduledAt: tomorrow},
+			userErr:     sql.ErrNoRows,
+			expectedErr: domain.ErrNotFound,
+		},
 		{
 			name:        "plan not found",
-			date:        tomorrow,
+			in:          domain.ScheduleInput{ScheduledAt: tomorrow, Timezone: "UTC"},
 			ownerErr:    sql.ErrNoRows,
 			expectedErr: domain.ErrNotFound,
 		},
 		{
 			name:        "unauthorized plan",
-			date:        tomorrow,
+			in:          domain.ScheduleInput{ScheduledAt: tomorrow, Timezone: "UTC"},
 			ownerID:     "someone-else",
 			expectedErr: domain.ErrForbidden,
 		},
 		{
 			name:        "duplicate schedule",
-			date:        tomorrow,
+			in:          domain.ScheduleInput{ScheduledAt: tomorrow, Timezone: "UTC"},
 			ownerID:     "u1",
 			existing:    []domain.ScheduledWorkout{{WorkoutPlanID: "p1"}},
 			expectedErr: domain.ErrConflict,
 		},
 		{
 			name:        "duplicate from repo create",
-			date:        tomorrow,
+			in:          domain.ScheduleInput{ScheduledAt: tomorrow, Timezone: "UTC"},
 			ownerID:     "u1",
 			createErr:   sql.ErrNoRows,
 			expectedErr: domain.ErrConflict,
@@ -77,8 +119,17 @@ func TestScheduledWorkoutUsecase_ScheduleWorkout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := new(mocks.MockScheduledWorkoutRepository)
 			checker := new(mocks.MockWorkoutPlanChecker)
+			userRepo := new(mocks.MockUserRepository)
+
+			if tt.in.Timezone == "" {
+				var user *domain.User
+				if tt.userErr == nil {
+					user = &domain.User{ID: "u1", Timezone: tt.userTimezone}
+				}
+				userRepo.On("GetByID", mock.Anything, "u1").Return(user, tt.userErr).Once()
+			}
 
-			if !errors.Is(tt.expectedErr, domain.ErrInvalidInput) {
+			if !errors.Is(tt.expectedErr, domain.ErrInvalidInput) && tt.userErr == nil {
 				checker.On("GetOwnerID", mock.Anything, "p1").Return(tt.ownerID, tt.ownerErr).Once()
 			}
 
@@ -91,11 +142,16 @@ func TestScheduledWorkoutUsecase_ScheduleWorkout(t *testing.T) {
 				repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.ScheduledWorkout")).Return(tt.createErr)
 			}
 
-			uc := usecase.NewScheduledWorkoutUsecase(repo, checker)
-			err := uc.ScheduleWorkout(context.Background(), "u1", "p1", tt.date)
+			uc := usecase.NewScheduledWorkoutUsecase(repo, checker, new(mocks.MockWorkoutSessionRepository), userRepo, new(mocks.MockScheduleSeriesRepository))
+			in := tt.in
+			in.WorkoutPlanID = "p1"
+			sw, err := uc.ScheduleWorkout(context.Background(), "u1", in)
 
 			if tt.expectedErr == nil {
 				require.NoError(t, err)
+				assert.Equal(t, tt.expectedDate, sw.ScheduledDate)
+				assert.Equal(t, tt.expectedTZ, sw.Timezone)
+				assert.Equal(t, tt.in.AllDay, sw.AllDay)
 			} else {
 				require.Error(t, err)
 				assert.True(t, errors.Is(err, tt.expectedErr))
@@ -103,6 +159,105 @@ func TestScheduledWorkoutUsecase_ScheduleWorkout(t *testing.T) {
 
 			repo.AssertExpectations(t)
 			checker.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduledWorkoutUsecase_RescheduleWorkout(t *testing.T) {
+	t.Parallel()
+
+	today := domain.CivilDate(time.Now().UTC())
+	current := today.AddDate(0, 0, 2)
+	target := today.AddDate(0, 0, 5).Add(9 * time.Hour)
+	past := today.AddDate(0, 0, -1).Add(9 * time.Hour)
+	row := func(status, seriesID string) *domain.ScheduledWorkout {
+		return &domain.ScheduledWorkout{
+			ID: "s1", UserID: "u1", WorkoutPlanID: "p1", ScheduledDate: current,
+			ScheduledAt: current.Add(7 * time.Hour), Timezone: "UTC", Status: status, SeriesID: seriesID,
+		}
+	}
+	planP2 := "p2"
+
+	tests := []struct {
+		name         string
+		row          *domain.ScheduledWorkout
+		rowErr       error
+		change       domain.ScheduleChange
+		ownerID      string
+		existing     []domain.ScheduledWorkout
+		updateErr    error
+		expectDetach bool
+		expectedErr  error
+	}{
+		{name: "moves date", row: row(domain.ScheduleStatusPending, ""), change: domain.ScheduleChange{ScheduledAt: &target}},
+		{
+			name:         "detaches series occurrence",
+			row:          row(domain.ScheduleStatusPending, "series-1"),
+			change:       domain.ScheduleChange{ScheduledAt: &target},
+			expectDetach: true,
+		},
+		{name: "changes plan", row: row(domain.ScheduleStatusPending, ""), change: domain.ScheduleChange{WorkoutPlanID: &planP2}, ownerID: "u1"},
+		{name: "plan of another user", row: row(domain.ScheduleStatusPending, ""), change: domain.ScheduleChange{WorkoutPlanID: &planP2}, ownerID: "u2", expectedErr: domain.ErrForbidden},
+		{name: "not found", rowErr: sql.ErrNoRows, expectedErr: domain.ErrNotFound},
+		{name: "not pending", row: row(domain.ScheduleStatusCompleted, ""), change: domain.ScheduleChange{ScheduledAt: &target}, expectedErr: domain.ErrConflict},
+		{name: "date in the past", row: row(domain.ScheduleStatusPending, ""), change: domain.ScheduleChange{ScheduledAt: &past}, expectedErr: domain.ErrInvalidInput},
+		{
+			name:        "target day already has plan",
+			row:         row(domain.ScheduleStatusPending, ""),
+			change:      domain.ScheduleChange{ScheduledAt: &target},
+			existing:    []domain.ScheduledWorkout{{ID: "s2", WorkoutPlanID: "p1"}},
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name:        "concurrent duplicate",
+			row:         row(domain.ScheduleStatusPending, ""),
+			change:      domain.ScheduleChange{ScheduledAt: &target},
+			updateErr:   sql.ErrNoRows,
+			expectedErr: domain.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockScheduledWorkoutRepository)
+			checker := new(mocks.MockWorkoutPlanChecker)
+			uc := usecase.NewScheduledWorkoutUsecase(repo, checker, new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), new(mocks.MockScheduleSeriesRepository))
+
+			repo.On("GetByID", mock.Anything, "s1", "u1").Return(tt.row, tt.rowErr).Once()
+			if tt.ownerID != "" {
+				checker.On("GetOwnerID", mock.Anything, "p2").Return(tt.ownerID, nil).Once()
+			}
+			reachesDuplicateCheck := tt.row != nil && tt.row.Status == domain.ScheduleStatusPending &&
+				tt.ownerID != "u2" && tt.change.ScheduledAt != &past
+			if reachesDuplicateCheck {
+				repo.On("GetByUser", mock.Anything, "u1", mock.Anything, mock.Anything).
+					Return(domain.NewPaginatedResult(tt.existing, len(tt.existing), domain.NewPagination(1, 100)), nil).Once()
+				if len(tt.existing) == 0 {
+					repo.On("Update", mock.Anything, mock.AnythingOfType("*domain.ScheduledWorkout")).Return(tt.updateErr).Once()
+				}
+			}
+
+			sw, err := uc.RescheduleWorkout(context.Background(), "s1", "u1", tt.change)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "s1", sw.ID)
+				assert.Equal(t, tt.expectDetach, sw.Detached)
+				if tt.change.ScheduledAt != nil {
+					assert.Equal(t, domain.CivilDate(target), sw.ScheduledDate)
+				}
+				if tt.change.WorkoutPlanID != nil {
+					assert.Equal(t, "p2", sw.WorkoutPlanID)
+				}
+			}
+			repo.AssertExpectations(t)
+			checker.AssertExpectations(t)
 		})
 	}
 }
@@ -112,7 +267,7 @@ func TestScheduledWorkoutUsecase_DeleteSchedule(t *testing.T) {
 
 	repo := new(mocks.MockScheduledWorkoutRepository)
 	checker := new(mocks.MockWorkoutPlanChecker)
-	uc := usecase.NewScheduledWorkoutUsecase(repo, checker)
+	uc := usecase.NewScheduledWorkoutUsecase(repo, checker, new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), new(mocks.MockScheduleSeriesRepository))
 
 	repo.On("Delete", mock.Anything, "s1", "u1").Return(nil).Once()
 	err := uc.DeleteSchedule(context.Background(), "s1", "u1")
@@ -120,9 +275,336 @@ func TestScheduledWorkoutUsecase_DeleteSchedule(t *testing.T) {
 	repo.AssertExpectations(t)
 
 	repo2 := new(mocks.MockScheduledWorkoutRepository)
-	uc2 := usecase.NewScheduledWorkoutUsecase(repo2, checker)
+	uc2 := usecase.NewScheduledWorkoutUsecase(repo2, checker, new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), new(mocks.MockScheduleSeriesRepository))
 	repo2.On("Delete", mock.Anything, "s1", "u1").Return(sql.ErrNoRows).Once()
 	err = uc2.DeleteSchedule(context.Background(), "s1", "u1")
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, domain.ErrNotFound))
 }
+
+func TestScheduledWorkoutUsecase_Transitions(t *testing.T) {
+	t.Parallel()
+
+	completedAt := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		action      string
+		current     string
+		getErr      error
+		sessionID   string
+		session     *domain.WorkoutSession
+		sessionErr  error
+		reason      string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "complete pending",
+			action:   "complete",
+			current:  domain.ScheduleStatusPending,
+			expected: domain.ScheduleStatusCompleted,
+		},
+		{
+			name:      "complete with finished session",
+			action:    "complete",
+			current:   domain.ScheduleStatusMissed,
+			sessionID: "ws1",
+			session:   &domain.WorkoutSession{ID: "ws1", CompletedAt: &completedAt},
+			expected:  domain.ScheduleStatusCompleted,
+		},
+		{
+			name:        "complete with unfinished session",
+			action:      "complete",
+			sessionID:   "ws1",
+			session:     &domain.WorkoutSession{ID: "ws1"},
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name:        "complete with unknown session",
+			action:      "complete",
+			sessionID:   "ws1",
+			sessionErr:  sql.ErrNoRows,
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name:        "complete canceled",
+			action:      "complete",
+			current:     domain.ScheduleStatusCanceled,
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name:     "cancel pending",
+			action:   "cancel",
+			current:  domain.ScheduleStatusPending,
+			reason:   " sick ",
+			expected: domain.ScheduleStatusCanceled,
+		},
+		{
+			name:        "cancel completed",
+			action:      "cancel",
+			current:     domain.ScheduleStatusCompleted,
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name:        "cancel not found",
+			action:      "cancel",
+			getErr:      sql.ErrNoRows,
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name:     "reopen canceled",
+			action:   "reopen",
+			current:  domain.ScheduleStatusCanceled,
+			expected: domain.ScheduleStatusPending,
+		},
+		{
+			name:        "reopen pending",
+			action:      "reopen",
+			current:     domain.ScheduleStatusPending,
+			expectedErr: domain.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockScheduledWorkoutRepository)
+			checker := new(mocks.MockWorkoutPlanChecker)
+			sessionRepo := new(mocks.MockWorkoutSessionRepository)
+
+			if tt.sessionID != "" {
+				sessionRepo.On("GetByID", mock.Anything, tt.sessionID, "u1").Return(tt.session, tt.sessionErr).Once()
+			}
+
+			sessionBlocked := tt.sessionErr != nil || (tt.session != nil && !tt.session.IsFinished())
+			if !sessionBlocked {
+				var sw *domain.ScheduledWorkout
+				if tt.getErr == nil {
+					sw = &domain.ScheduledWorkout{ID: "s1", UserID: "u1", WorkoutPlanID: "p1", Status: tt.current, CancelReason: "old"}
+				}
+				repo.On("GetByID", mock.Anything, "s1", "u1").Return(sw, tt.getErr).Once()
+			}
+			if tt.expectedErr == nil {
+				repo.On("UpdateStatus", mock.Anything, mock.AnythingOfType("*domain.ScheduledWorkout")).Return(nil).Once()
+			}
+
+			uc := usecase.NewScheduledWorkoutUsecase(repo, checker, sessionRepo, new(mocks.MockUserRepository), new(mocks.MockScheduleSeriesRepository))
+
+			var (
+				sw  *domain.ScheduledWorkout
+				err error
+			)
+			switch tt.action {
+			case "complete":
+				sw, err = uc.CompleteSchedule(context.Background(), "s1", "u1", tt.sessionID)
+			case "cancel":
+				sw, err = uc.CancelSchedule(context.Background(), "s1", "u1", tt.reason)
+			case "reopen":
+				sw, err = uc.ReopenSchedule(context.Background(), "s1", "u1")
+			}
+
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, sw.Status)
+				assert.Equal(t, tt.sessionID, sw.WorkoutSessionID)
+				if tt.action == "cancel" {
+					assert.Equal(t, "sick", sw.CancelReason)
+				} else {
+					assert.Empty(t, sw.CancelReason)
+				}
+			} else {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			}
+
+			repo.AssertExpectations(t)
+			sessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduledWorkoutUsecase_GetSchedulesStatusFilter(t *testing.T) {
+	t.Parallel()
+
+	repo := new(mocks.MockScheduledWorkoutRepository)
+	seriesRepo := new(mocks.MockScheduleSeriesRepository)
+	uc := usecase.NewScheduledWorkoutUsecase(repo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), seriesRepo)
+
+	_, err := uc.GetSchedules(context.Background(), "u1", domain.NewPagination(1, 10), domain.ScheduledWorkoutFilter{Status: "unknown"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+
+	filter := domain.ScheduledWorkoutFilter{Status: domain.ScheduleStatusMissed}
+	seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+	repo.On("GetByUser", mock.Anything, "u1", mock.Anything, filter).
+		Return(domain.NewPaginatedResult([]domain.ScheduledWorkout{}, 0, domain.NewPagination(1, 10)), nil).Once()
+	_, err = uc.GetSchedules(context.Background(), "u1", domain.NewPagination(1, 10), filter)
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	seriesRepo.AssertExpectations(t)
+}
+
+func TestScheduledWorkoutUsecase_GetSchedulesRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	far := time.Date(2999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		filter      domain.ScheduledWorkoutFilter
+		expectedErr error
+	}{
+		{name: "ascending range", filter: domain.ScheduledWorkoutFilter{From: &from, To: &to, Sort: domain.SortAsc}},
+		{name: "open ended", filter: domain.ScheduledWorkoutFilter{From: &from}},
+		{name: "reversed range", filter: domain.ScheduledWorkoutFilter{From: &to, To: &from}, expectedErr: domain.ErrInvalidInput},
+		{name: "unknown sort", filter: domain.ScheduledWorkoutFilter{Sort: "sideways"}, expectedErr: domain.ErrInvalidInput},
+		{name: "date past max horizon", filter: domain.ScheduledWorkoutFilter{Date: &far}, expectedErr: domain.ErrInvalidInput},
+		{name: "range past max horizon", filter: domain.ScheduledWorkoutFilter{From: &from, To: &far}, expectedErr: domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockScheduledWorkoutRepository)
+			seriesRepo := new(mocks.MockScheduleSeriesRepository)
+			uc := usecase.NewScheduledWorkoutUsecase(repo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), seriesRepo)
+
+			if tt.expectedErr == nil {
+				seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+				repo.On("GetByUser", mock.Anything, "u1", mock.Anything, tt.filter).
+					Return(domain.NewPaginatedResult([]domain.ScheduledWorkout{}, 0, domain.NewPagination(1, 10)), nil).Once()
+			}
+
+			_, err := uc.GetSchedules(context.Background(), "u1", domain.NewPagination(1, 10), tt.filter)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+			}
+			repo.AssertExpectations(t)
+			seriesRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduledWorkoutUsecase_GetAgenda(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults to pending from today in user timezone", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(mocks.MockScheduledWorkoutRepository)
+		seriesRepo := new(mocks.MockScheduleSeriesRepository)
+		userRepo := new(mocks.MockUserRepository)
+		uc := usecase.NewScheduledWorkoutUsecase(repo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), userRepo, seriesRepo)
+
+		loc, err := time.LoadLocation("Pacific/Kiritimati")
+		require.NoError(t, err)
+		today := domain.CivilDate(time.Now().In(loc))
+
+		userRepo.On("GetByID", mock.Anything, "u1").Return(&domain.User{ID: "u1", Timezone: "Pacific/Kiritimati"}, nil).Once()
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+		items := []domain.AgendaItem{{ScheduledWorkout: domain.ScheduledWorkout{ID: "s1"}, PlanName: "Push Day"}}
+		repo.On("GetAgenda", mock.Anything, "u1", mock.Anything, domain.ScheduledWorkoutFilter{
+			From: &today, Status: domain.ScheduleStatusPending, Sort: domain.SortAsc,
+		}).Return(domain.NewPaginatedResult(items, 1, domain.NewPagination(1, 10)), nil).Once()
+
+		res, err := uc.GetAgenda(context.Background(), "u1", domain.NewPagination(1, 10), domain.ScheduledWorkoutFilter{})
+		require.NoError(t, err)
+		require.Len(t, res.Data, 1)
+		assert.Equal(t, "Push Day", res.Data[0].PlanName)
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("explicit range keeps status and sort", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(mocks.MockScheduledWorkoutRepository)
+		seriesRepo := new(mocks.MockScheduleSeriesRepository)
+		uc := usecase.NewScheduledWorkoutUsecase(repo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), seriesRepo)
+
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		filter := domain.ScheduledWorkoutFilter{From: &from, Status: domain.ScheduleStatusCompleted, Sort: domain.SortDesc}
+		seriesRepo.On("GetByUser", mock.Anything, "u1").Return([]domain.ScheduleSeries{}, nil).Once()
+		repo.On("GetAgenda", mock.Anything, "u1", mock.Anything, filter).
+			Return(domain.NewPaginatedResult([]domain.AgendaItem{}, 0, domain.NewPagination(1, 10)), nil).Once()
+
+		_, err := uc.GetAgenda(context.Background(), "u1", domain.NewPagination(1, 10), filter)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+
+	t.Run("range past max horizon", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(mocks.MockScheduledWorkoutRepository)
+		seriesRepo := new(mocks.MockScheduleSeriesRepository)
+		uc := usecase.NewScheduledWorkoutUsecase(repo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), seriesRepo)
+
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2999, 12, 31, 0, 0, 0, 0, time.UTC)
+		_, err := uc.GetAgenda(context.Background(), "u1", domain.NewPagination(1, 10), domain.ScheduledWorkoutFilter{From: &from, To: &to})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+		seriesRepo.AssertExpectations(t)
+	})
+}
+
+func TestScheduledWorkoutUsecase_MarkMissed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		grace       time.Duration
+		repoCount   int
+		repoErr     error
+		expected    int
+		expectedErr error
+	}{
+		{name: "marks overdue", grace: 12 * time.Hour, repoCount: 3, expected: 3},
+		{name: "no grace", grace: 0, repoCount: 1, expected: 1},
+		{name: "negative grace", grace: -time.Hour, expectedErr: domain.ErrInvalidInput},
+		{name: "repo error", grace: time.Hour, repoErr: errors.New("db down")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockScheduledWorkoutRepository)
+			uc := usecase.NewScheduledWorkoutUsecase(repo, new(mocks.MockWorkoutPlanChecker), new(mocks.MockWorkoutSessionRepository), new(mocks.MockUserRepository), new(mocks.MockScheduleSeriesRepository))
+			if tt.expectedErr == nil {
+				repo.On("MarkMissed", mock.Anything, now, tt.grace).Return(tt.repoCount, tt.repoErr).Once()
+			}
+
+			n, err := uc.MarkMissed(context.Background(), now, tt.grace)
+			switch {
+			case tt.expectedErr != nil:
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			case tt.repoErr != nil:
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.repoErr))
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, n)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
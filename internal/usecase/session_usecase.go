@@ -0,0 +1,570 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/repository"
+)
+
+type SessionUsecase struct {
+	repo           repository.WorkoutSessionRepository
+	workoutRepo    domain.WorkoutRepository
+	exerciseRepo   domain.ExerciseRepository
+	recordRepo     repository.RecordRepository
+	rollupRepo     repository.RollupRepository
+	workoutUsecase *WorkoutUsecase
+	logger         *slog.Logger
+}
+
+func NewSessionUsecase(repo repository.WorkoutSessionRepository, workoutRepo domain.WorkoutRepository, exerciseRepo domain.ExerciseRepository, recordRepo repository.RecordRepository, rollupRepo repository.RollupRepository, workoutUC *WorkoutUsecase, logger *slog.Logger) *SessionUsecase {
+	return &SessionUsecase{repo: repo, workoutRepo: workoutRepo, exerciseRepo: exerciseRepo, recordRepo: recordRepo, rollupRepo: rollupRepo, workoutUsecase: workoutUC, logger: logger}
+}
+
+func (u *SessionUsecase) StartSession(ctx context.Context, userID, workoutPlanID, notes string) (*domain.WorkoutSession, error) {
+	userID = strings.TrimSpace(userID)
+	workoutPlanID = strings.TrimSpace(workoutPlanID)
+	notes = strings.TrimSpace(notes)
+
+	if userID == "" {
+		return nil, fmt.Errorf("start session: %w", domain.ErrInvalidInput)
+	}
+
+	s := &domain.WorkoutSession{
+		UserID:    userID,
+		StartedAt: time.Now().UTC(),
+		Notes:     notes,
+		Exercises: []domain.WorkoutSessionExercise{},
+	}
+
+	if workoutPlanID != "" {
+		version, exercises, err := u.planTargets(ctx, userID, workoutPlanID)
+		if err != nil {
+			return nil, fmt.Errorf("start session: %w", err)
+		}
+		s.WorkoutPlanID = workoutPlanID
+		s.PlanVersion = version
+		s.Exercises = exercises
+	}
+
+	if err := u.repo.Create(ctx, s); err != nil {
+		return nil, fmt.Errorf("start session: %w", err)
+	}
+
+	return s, nil
+}
+
+// planTargets reads the latest plan version so the targets match the
+// version recorded on the session.
+func (u *SessionUsecase) planTargets(ctx context.Context, userID, workoutPlanID string) (int, []domain.WorkoutSessionExercise, error) {
+	plan, err := u.workoutRepo.GetPlanByID(ctx, workoutPlanID, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if plan == nil {
+		return 0, nil, domain.ErrNotFound
+	}
+
+	v, err := u.workoutRepo.GetLatestPlanVersion(ctx, plan.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+	if v == nil {
+		return 0, nil, domain.ErrNotFound
+	}
+
+	exercises := make([]domain.WorkoutSessionExercise, 0, len(v.Exercises))
+	for _, ve := range v.Exercises {
+		pe := ve.WorkoutPlanExercise
+		exercises = append(exercises, domain.WorkoutSessionExercise{
+			ExerciseID: pe.ExerciseID,
+			Sets:       pe.Sets,
+			Reps:       pe.Reps,
+			Weight:     pe.Weight,
+			OrderIndex: pe.OrderIndex,
+			Group:      pe.Group,
+			SetLogs:    planTargetSets(pe),
+		})
+	}
+
+	return v.Version, exercises, nil
+}
+
+func (u *SessionUsecase) AddExercise(ctx context.Context, userID, sessionID, exerciseID string, sets, reps int, weight float64) (*domain.WorkoutSessionExercise, error) {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+	exerciseID = strings.TrimSpace(exerciseID)
+
+	if userID == "" || sessionID == "" || exerciseID == "" {
+		return nil, fmt.Errorf("add session exercise: %w", domain.ErrInvalidInput)
+	}
+	if sets <= 0 || reps <= 0 || weight < 0 {
+		return nil, fmt.Errorf("add session exercise: %w", domain.ErrInvalidInput)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("add session exercise: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("add session exercise: %w", err)
+	}
+	if s.IsFinished() {
+		return nil, fmt.Errorf("add session exercise: %w", domain.ErrConflict)
+	}
+
+	if _, err := u.exerciseRepo.GetByID(ctx, exerciseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("add session exercise: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("add session exercise: %w", err)
+	}
+
+	ex := &domain.WorkoutSessionExercise{
+		WorkoutSessionID: s.ID,
+		ExerciseID:       exerciseID,
+		Sets:             sets,
+		Reps:             reps,
+		Weight:           weight,
+		SetLogs:          targetSets(sets, reps, weight),
+	}
+
+	if err := u.repo.AddExercise(ctx, ex); err != nil {
+		return nil, fmt.Errorf("add session exercise: %w", err)
+	}
+
+	return ex, nil
+}
+
+func (u *SessionUsecase) SaveAsPlan(ctx context.Context, userID, sessionID, name, notes string) (*domain.WorkoutPlan, error) {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+
+	if userID == "" || sessionID == "" {
+		return nil, fmt.Errorf("save session as plan: %w", domain.ErrInvalidInput)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("save session as plan: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("save session as plan: %w", err)
+	}
+	if !s.IsFinished() {
+		return nil, fmt.Errorf("save session as plan: %w", domain.ErrConflict)
+	}
+	if len(s.Exercises) < 1 {
+		return nil, fmt.Errorf("save session as plan: %w", domain.ErrInvalidInput)
+	}
+
+	exercises := make([]domain.WorkoutPlanExercise, 0, len(s.Exercises))
+	for i, ex := range s.Exercises {
+		pe := planExerciseFromSession(ex)
+		pe.OrderIndex = i
+		exercises = append(exercises, pe)
+	}
+
+	plan, err := u.workoutUsecase.CreatePlan(ctx, userID, name, notes, exercises)
+	if err != nil {
+		return nil, fmt.Errorf("save session as plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+func (u *SessionUsecase) RecordExercise(ctx context.Context, userID, sessionID, sessionExerciseID string, actualReps *int, actualWeight *float64) error {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+	sessionExerciseID = strings.TrimSpace(sessionExerciseID)
+
+	if userID == "" || sessionID == "" || sessionExerciseID == "" {
+		return fmt.Errorf("record exercise: %w", domain.ErrInvalidInput)
+	}
+	if actualReps != nil && *actualReps < 0 {
+		return fmt.Errorf("record exercise: %w", domain.ErrInvalidInput)
+	}
+	if actualWeight != nil && *actualWeight < 0 {
+		return fmt.Errorf("record exercise: %w", domain.ErrInvalidInput)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("record exercise: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("record exercise: %w", err)
+	}
+	if s.IsFinished() {
+		return fmt.Errorf("record exercise: %w", domain.ErrConflict)
+	}
+
+	ex := &domain.WorkoutSessionExercise{
+		ID:           sessionExerciseID,
+		ActualReps:   actualReps,
+		ActualWeight: actualWeight,
+	}
+
+	if err := u.repo.UpdateExerciseActuals(ctx, s.ID, ex); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("record exercise: %w", domain.ErrNotFound)
+		}
+		return fmt.Errorf("record exercise: %w", err)
+	}
+
+	return nil
+}
+
+func (u *SessionUsecase) AddSet(ctx context.Context, userID, sessionID, sessionExerciseID string, in domain.SessionSetInput) (*domain.WorkoutSessionSet, error) {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+	sessionExerciseID = strings.TrimSpace(sessionExerciseID)
+
+	if userID == "" || sessionID == "" || sessionExerciseID == "" {
+		return nil, fmt.Errorf("add set: %w", domain.ErrInvalidInput)
+	}
+	if err := validateSetInput(in); err != nil {
+		return nil, fmt.Errorf("add set: %w", err)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("add set: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("add set: %w", err)
+	}
+	if s.IsFinished() {
+		return nil, fmt.Errorf("add set: %w", domain.ErrConflict)
+	}
+	if _, ok := s.FindExercise(sessionExerciseID); !ok {
+		return nil, fmt.Errorf("add set: %w", domain.ErrNotFound)
+	}
+
+	st := &domain.WorkoutSessionSet{WorkoutSessionExerciseID: sessionExerciseID}
+	applySetInput(st, in, time.Now().UTC())
+	st.TargetReps = in.TargetReps
+	st.TargetWeight = in.TargetWeight
+
+	if err := u.repo.AddSet(ctx, st); err != nil {
+		return nil, fmt.Errorf("add set: %w", err)
+	}
+
+	return st, nil
+}
+
+func (u *SessionUsecase) LogSet(ctx context.Context, userID, sessionID, setID string, in domain.SessionSetInput) (*domain.WorkoutSessionSet, error) {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+	setID = strings.TrimSpace(setID)
+
+	if userID == "" || sessionID == "" || setID == "" {
+		return nil, fmt.Errorf("log set: %w", domain.ErrInvalidInput)
+	}
+	if in.TargetReps != nil || in.TargetWeight != nil {
+		return nil, fmt.Errorf("log set: %w", domain.ErrInvalidInput)
+	}
+	if err := validateSetInput(in); err != nil {
+		return nil, fmt.Errorf("log set: %w", err)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("log set: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("log set: %w", err)
+	}
+	if s.IsFinished() {
+		return nil, fmt.Errorf("log set: %w", domain.ErrConflict)
+	}
+
+	st, ok := s.FindSet(setID)
+	if !ok {
+		return nil, fmt.Errorf("log set: %w", domain.ErrNotFound)
+	}
+
+	applySetInput(st, in, time.Now().UTC())
+
+	if err := u.repo.UpdateSet(ctx, st); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("log set: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("log set: %w", err)
+	}
+
+	return st, nil
+}
+
+// FinishSession completes the session and returns it with the personal
+// records it set.
+func (u *SessionUsecase) FinishSession(ctx context.Context, userID, sessionID, notes string) (*domain.WorkoutSession, []domain.PersonalRecord, error) {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+	notes = strings.TrimSpace(notes)
+
+	if userID == "" || sessionID == "" {
+		return nil, nil, fmt.Errorf("finish session: %w", domain.ErrInvalidInput)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("finish session: %w", domain.ErrNotFound)
+		}
+		return nil, nil, fmt.Errorf("finish session: %w", err)
+	}
+	if s.IsFinished() {
+		return nil, nil, fmt.Errorf("finish session: %w", domain.ErrConflict)
+	}
+
+	if notes == "" {
+		notes = s.Notes
+	}
+
+	completedAt := time.Now().UTC()
+	if completedAt.Before(s.StartedAt) {
+		completedAt = s.StartedAt
+	}
+
+	s.CompletedAt = &completedAt
+	s.Notes = notes
+
+	records, err := u.detectPersonalRecords(ctx, s)
+	if err != nil {
+		return nil, nil, fmt.Errorf("finish session: %w", err)
+	}
+
+	if err := u.repo.Finish(ctx, s.ID, userID, completedAt, notes, records); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("finish session: %w", domain.ErrConflict)
+		}
+		return nil, nil, fmt.Errorf("finish session: %w", err)
+	}
+
+	u.refreshRollups(ctx, userID, s.ID)
+
+	return s, records, nil
+}
+
+// refreshRollups invalidates the rollups when the refresh fails, so reports
+// fall back to the sessions.
+func (u *SessionUsecase) refreshRollups(ctx context.Context, userID, sessionID string) {
+	err := u.rollupRepo.RefreshSession(ctx, userID, sessionID)
+	if err == nil {
+		return
+	}
+	u.logger.Error("rollup_refresh_failed", "user_id", userID, "session_id", sessionID, "error", err.Error())
+
+	if err := u.rollupRepo.Invalidate(ctx, userID); err != nil {
+		u.logger.Error("rollup_invalidate_failed", "user_id", userID, "error", err.Error())
+	}
+}
+
+// detectPersonalRecords returns the records the finished session beats,
+// for the repository to store along with the finish.
+func (u *SessionUsecase) detectPersonalRecords(ctx context.Context, s *domain.WorkoutSession) ([]domain.PersonalRecord, error) {
+	if len(s.Exercises) == 0 {
+		return []domain.PersonalRecord{}, nil
+	}
+
+	exerciseIDs := make([]string, 0, len(s.Exercises))
+	for _, ex := range s.Exercises {
+		exerciseIDs = append(exerciseIDs, ex.ExerciseID)
+	}
+
+	current, err := u.recordRepo.GetCurrent(ctx, s.UserID, exerciseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := domain.DetectRecords(*s, current)
+	if records == nil {
+		return []domain.PersonalRecord{}, nil
+	}
+	return records, nil
+}
+
+func (u *SessionUsecase) GetSessions(ctx context.Context, userID string, pagination domain.Pagination, filters domain.WorkoutSessionFilter) (domain.PaginatedResult[domain.WorkoutSession], error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions: %w", domain.ErrInvalidInput)
+	}
+
+	switch filters.Status {
+	case "", domain.SessionStatusInProgress, domain.SessionStatusCompleted:
+	default:
+		return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions: %w", domain.ErrInvalidInput)
+	}
+
+	res, err := u.repo.GetByUser(ctx, userID, pagination, filters)
+	if err != nil {
+		return domain.PaginatedResult[domain.WorkoutSession]{}, fmt.Errorf("get sessions: %w", err)
+	}
+	return res, nil
+}
+
+func (u *SessionUsecase) GetSessionByID(ctx context.Context, userID, sessionID string) (*domain.WorkoutSession, error) {
+	userID = strings.TrimSpace(userID)
+	sessionID = strings.TrimSpace(sessionID)
+
+	if userID == "" || sessionID == "" {
+		return nil, fmt.Errorf("get session: %w", domain.ErrInvalidInput)
+	}
+
+	s, err := u.repo.GetByID(ctx, sessionID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get session: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+
+	return s, nil
+}
+
+func targetSets(sets, reps int, weight float64) []domain.WorkoutSessionSet {
+	out := make([]domain.WorkoutSessionSet, 0, sets)
+	for i := 1; i <= sets; i++ {
+		r := reps
+		w := weight
+		out = append(out, domain.WorkoutSessionSet{
+			SetNumber:    i,
+			TargetReps:   &r,
+			TargetWeight: &w,
+		})
+	}
+	return out
+}
+
+// planTargetSets lays out a plan exercise's sets for a session, following its
+// per-set prescription when it has one.
+func planTargetSets(pe domain.WorkoutPlanExercise) []domain.WorkoutSessionSet {
+	if len(pe.SetPrescriptions) == 0 {
+		return targetSets(pe.Sets, pe.Reps, pe.Weight)
+	}
+
+	out := make([]domain.WorkoutSessionSet, 0, len(pe.SetPrescriptions))
+	for i, p := range pe.SetPrescriptions {
+		reps := p.Reps
+		st := domain.WorkoutSessionSet{
+			SetNumber:    i + 1,
+			TargetReps:   &reps,
+			TargetWeight: pe.TargetWeight(p),
+			TargetRPE:    p.RPE,
+			IsWarmup:     p.IsWarmup(),
+			SetType:      p.Type,
+		}
+		if p.RepsMax > 0 {
+			repsMax := p.RepsMax
+			st.TargetRepsMax = &repsMax
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+// planExerciseFromSession prescribes what was actually done: the number of
+// completed working sets at the heaviest weight lifted, falling back to the
+// session targets when nothing was logged.
+func planExerciseFromSession(ex domain.WorkoutSessionExercise) domain.WorkoutPlanExercise {
+	pe := domain.WorkoutPlanExercise{
+		ExerciseID: ex.ExerciseID,
+		Sets:       ex.Sets,
+		Reps:       ex.Reps,
+		Weight:     ex.Weight,
+		Group:      ex.Group,
+	}
+
+	working := 0
+	var top *domain.WorkoutSessionSet
+	for i := range ex.SetLogs {
+		st := &ex.SetLogs[i]
+		if st.IsWarmup || !st.IsCompleted() || st.ActualReps == nil || *st.ActualReps <= 0 {
+			continue
+		}
+		working++
+		if top == nil || setWeight(*st) > setWeight(*top) {
+			top = st
+		}
+	}
+
+	if top != nil {
+		pe.Sets = working
+		pe.Reps = *top.ActualReps
+		pe.Weight = setWeight(*top)
+	}
+
+	return pe
+}
+
+func setWeight(st domain.WorkoutSessionSet) float64 {
+	if st.ActualWeight != nil {
+		return *st.ActualWeight
+	}
+	if st.TargetWeight != nil {
+		return *st.TargetWeight
+	}
+	return 0
+}
+
+func validateSetInput(in domain.SessionSetInput) error {
+	if in.TargetReps != nil && *in.TargetReps <= 0 {
+		return domain.ErrInvalidInput
+	}
+	if in.TargetWeight != nil && *in.TargetWeight < 0 {
+		return domain.ErrInvalidInput
+	}
+	if in.ActualReps != nil && *in.ActualReps < 0 {
+		return domain.ErrInvalidInput
+	}
+	if in.ActualWeight != nil && *in.ActualWeight < 0 {
+		return domain.ErrInvalidInput
+	}
+	if in.RPE != nil && (*in.RPE < 1 || *in.RPE > 10) {
+		return domain.ErrInvalidInput
+	}
+	if in.RIR != nil && *in.RIR < 0 {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
+
+func applySetInput(st *domain.WorkoutSessionSet, in domain.SessionSetInput, now time.Time) {
+	if in.ActualReps != nil {
+		st.ActualReps = in.ActualReps
+	}
+	if in.ActualWeight != nil {
+		st.ActualWeight = in.ActualWeight
+	}
+	if in.RPE != nil {
+		st.RPE = in.RPE
+	}
+	if in.RIR != nil {
+		st.RIR = in.RIR
+	}
+	if in.IsWarmup != nil {
+		st.IsWarmup = *in.IsWarmup
+		// Keep a prescribed set type in line with the warm-up flag.
+		switch {
+		case st.IsWarmup && st.SetType != "":
+			st.SetType = domain.SetTypeWarmup
+		case !st.IsWarmup && st.SetType == domain.SetTypeWarmup:
+			st.SetType = domain.SetTypeWorking
+		}
+	}
+
+	switch {
+	case in.CompletedAt != nil:
+		t := in.CompletedAt.UTC()
+		st.CompletedAt = &t
+	case st.CompletedAt == nil && st.ActualReps != nil:
+		st.CompletedAt = &now
+	}
+}
@@ -0,0 +1,546 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"workout-tracker/internal/domain"
+	"workout-tracker/internal/mocks"
+	"workout-tracker/internal/usecase"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func newSessionUsecase(repo *mocks.MockWorkoutSessionRepository, workoutRepo *mocks.MockWorkoutRepository, exerciseRepo *mocks.MockExerciseRepository) *usecase.SessionUsecase {
+	return usecase.NewSessionUsecase(repo, workoutRepo, exerciseRepo, new(mocks.MockRecordRepository), new(mocks.MockRollupRepository), usecase.NewWorkoutUsecase(workoutRepo), discardLogger)
+}
+
+func TestSessionUsecase_StartSession(t *testing.T) {
+	t.Parallel()
+
+	superset := &domain.ExerciseGroup{ID: "A", Type: domain.GroupSuperset, Rounds: 3, RestSeconds: 90}
+	tm, warmupWeight, topPercent, topRPE := 140.0, 60.0, 90.0, 9.0
+	planExercises := []domain.WorkoutPlanExercise{
+		{ID: "pe1", ExerciseID: "e1", Sets: 3, Reps: 10, Weight: 60, OrderIndex: 0},
+		{ID: "pe2", ExerciseID: "e2", Sets: 4, Reps: 8, Weight: 40, OrderIndex: 1},
+		{ID: "pe3", ExerciseID: "e3", Sets: 3, Reps: 12, Weight: 20, OrderIndex: 2, Group: superset},
+		{ID: "pe4", ExerciseID: "e4", Sets: 3, Reps: 12, Weight: 15, OrderIndex: 3, Group: superset},
+		{ID: "pe5", ExerciseID: "e5", Sets: 2, Reps: 3, Weight: 60, OrderIndex: 4, TrainingMax: &tm, SetPrescriptions: []domain.SetPrescription{
+			{Type: domain.SetTypeWarmup, Reps: 5, Weight: &warmupWeight},
+			{Type: domain.SetTypeAMRAP, Reps: 3, RepsMax: 5, Percent: &topPercent, RPE: &topRPE},
+		}},
+	}
+	latest := &domain.WorkoutPlanVersion{WorkoutPlanID: "p1", Version: 3}
+	for _, pe := range planExercises {
+		latest.Exercises = append(latest.Exercises, domain.WorkoutPlanExerciseDetail{WorkoutPlanExercise: pe})
+	}
+
+	tests := []struct {
+		name        string
+		planID      string
+		setupMock   func(s *mocks.MockWorkoutSessionRepository, w *mocks.MockWorkoutRepository)
+		expectedErr error
+	}{
+		{
+			name:   "success copies plan exercises",
+			planID: "p1",
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, w *mocks.MockWorkoutRepository) {
+				w.On("GetPlanByID", mock.Anything, "p1", "u1").Return(&domain.WorkoutPlan{ID: "p1", UserID: "u1"}, nil).Once()
+				w.On("GetLatestPlanVersion", mock.Anything, "p1").Return(latest, nil).Once()
+				s.On("Create", mock.Anything, mock.AnythingOfType("*domain.WorkoutSession")).Return(nil).Run(func(args mock.Arguments) {
+					ws := args.Get(1).(*domain.WorkoutSession)
+					require.Len(t, ws.Exercises, 5)
+					assert.Equal(t, "p1", ws.WorkoutPlanID)
+					assert.Equal(t, 3, ws.PlanVersion)
+					assert.Equal(t, "e2", ws.Exercises[1].ExerciseID)
+					assert.Equal(t, 4, ws.Exercises[1].Sets)
+					require.Len(t, ws.Exercises[1].SetLogs, 4)
+					assert.Equal(t, 4, ws.Exercises[1].SetLogs[3].SetNumber)
+					assert.Equal(t, 8, *ws.Exercises[1].SetLogs[3].TargetReps)
+					assert.Nil(t, ws.Exercises[1].Group)
+					assert.Equal(t, superset, ws.Exercises[2].Group)
+					assert.Equal(t, superset, ws.Exercises[3].Group)
+					prescribed := ws.Exercises[4].SetLogs
+					require.Len(t, prescribed, 2)
+					assert.True(t, prescribed[0].IsWarmup)
+					assert.Equal(t, domain.SetTypeWarmup, prescribed[0].SetType)
+					assert.Equal(t, 60.0, *prescribed[0].TargetWeight)
+					assert.False(t, prescribed[1].IsWarmup)
+					assert.Equal(t, domain.SetTypeAMRAP, prescribed[1].SetType)
+					assert.Equal(t, 126.0, *prescribed[1].TargetWeight)
+					assert.Equal(t, 5, *prescribed[1].TargetRepsMax)
+					assert.Equal(t, 9.0, *prescribed[1].TargetRPE)
+					assert.Nil(t, ws.CompletedAt)
+					ws.ID = "s1"
+				}).Once()
+			},
+		},
+		{
+			name:   "ad-hoc session without plan",
+			planID: "",
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, w *mocks.MockWorkoutRepository) {
+				s.On("Create", mock.Anything, mock.AnythingOfType("*domain.WorkoutSession")).Return(nil).Run(func(args mock.Arguments) {
+					ws := args.Get(1).(*domain.WorkoutSession)
+					assert.Empty(t, ws.WorkoutPlanID)
+					assert.Empty(t, ws.Exercises)
+					ws.ID = "s1"
+				}).Once()
+			},
+		},
+		{
+			name:   "plan not found",
+			planID: "p1",
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, w *mocks.MockWorkoutRepository) {
+				w.On("GetPlanByID", mock.Anything, "p1", "u1").Return(nil, nil).Once()
+			},
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name:   "plan without versions",
+			planID: "p1",
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, w *mocks.MockWorkoutRepository) {
+				w.On("GetPlanByID", mock.Anything, "p1", "u1").Return(&domain.WorkoutPlan{ID: "p1", UserID: "u1"}, nil).Once()
+				w.On("GetLatestPlanVersion", mock.Anything, "p1").Return(nil, nil).Once()
+			},
+			expectedErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockWorkoutSessionRepository)
+			workoutRepo := new(mocks.MockWorkoutRepository)
+			tt.setupMock(repo, workoutRepo)
+
+			uc := newSessionUsecase(repo, workoutRepo, new(mocks.MockExerciseRepository))
+			s, err := uc.StartSession(context.Background(), "u1", tt.planID, "")
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, "s1", s.ID)
+			} else {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			}
+			repo.AssertExpectations(t)
+			workoutRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionUsecase_RecordExercise(t *testing.T) {
+	t.Parallel()
+
+	reps := 8
+	weight := 62.5
+	negative := -1
+	finishedAt := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		reps        *int
+		setupMock   func(s *mocks.MockWorkoutSessionRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			reps: &reps,
+			setupMock: func(s *mocks.MockWorkoutSessionRepository) {
+				s.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1"}, nil).Once()
+				s.On("UpdateExerciseActuals", mock.Anything, "s1", mock.AnythingOfType("*domain.WorkoutSessionExercise")).Return(nil).Once()
+			},
+		},
+		{
+			name:        "negative reps",
+			reps:        &negative,
+			setupMock:   func(s *mocks.MockWorkoutSessionRepository) {},
+			expectedErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "session finished",
+			reps: &reps,
+			setupMock: func(s *mocks.MockWorkoutSessionRepository) {
+				s.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1", CompletedAt: &finishedAt}, nil).Once()
+			},
+			expectedErr: domain.ErrConflict,
+		},
+		{
+			name: "exercise not in session",
+			reps: &reps,
+			setupMock: func(s *mocks.MockWorkoutSessionRepository) {
+				s.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1"}, nil).Once()
+				s.On("UpdateExerciseActuals", mock.Anything, "s1", mock.AnythingOfType("*domain.WorkoutSessionExercise")).Return(sql.ErrNoRows).Once()
+			},
+			expectedErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockWorkoutSessionRepository)
+			tt.setupMock(repo)
+
+			uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+			err := uc.RecordExercise(context.Background(), "u1", "s1", "se1", tt.reps, &weight)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionUsecase_LogSet(t *testing.T) {
+	t.Parallel()
+
+	session := func() *domain.WorkoutSession {
+		return &domain.WorkoutSession{
+			ID: "s1",
+			Exercises: []domain.WorkoutSessionExercise{{
+				ID: "se1",
+				SetLogs: []domain.WorkoutSessionSet{
+					{ID: "set1", WorkoutSessionExerciseID: "se1", SetNumber: 1},
+					{ID: "set2", WorkoutSessionExerciseID: "se1", SetNumber: 2},
+				},
+			}},
+		}
+	}
+
+	reps := 5
+	weight := 100.0
+	rpe := 8.5
+	badRPE := 11.0
+	warmup := true
+
+	t.Run("success marks set completed", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(session(), nil).Once()
+		repo.On("UpdateSet", mock.Anything, mock.AnythingOfType("*domain.WorkoutSessionSet")).Return(nil).Once()
+
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		st, err := uc.LogSet(context.Background(), "u1", "s1", "set2", domain.SessionSetInput{
+			ActualReps:   &reps,
+			ActualWeight: &weight,
+			RPE:          &rpe,
+			IsWarmup:     &warmup,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, st.SetNumber)
+		assert.Equal(t, 5, *st.ActualReps)
+		assert.True(t, st.IsWarmup)
+		assert.True(t, st.IsCompleted())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("finished session is read only", func(t *testing.T) {
+		done := time.Now().UTC()
+		finished := session()
+		finished.CompletedAt = &done
+
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(finished, nil).Twice()
+
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		_, err := uc.LogSet(context.Background(), "u1", "s1", "set1", domain.SessionSetInput{ActualReps: &reps, ActualWeight: &weight})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrConflict))
+
+		_, err = uc.AddSet(context.Background(), "u1", "s1", "se1", domain.SessionSetInput{ActualReps: &reps, ActualWeight: &weight})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrConflict))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid rpe", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		_, err := uc.LogSet(context.Background(), "u1", "s1", "set1", domain.SessionSetInput{RPE: &badRPE})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("set not in session", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(session(), nil).Once()
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		_, err := uc.LogSet(context.Background(), "u1", "s1", "other", domain.SessionSetInput{ActualReps: &reps})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("add set to exercise", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(session(), nil).Once()
+		repo.On("AddSet", mock.Anything, mock.AnythingOfType("*domain.WorkoutSessionSet")).Return(nil).Run(func(args mock.Arguments) {
+			st := args.Get(1).(*domain.WorkoutSessionSet)
+			assert.Equal(t, "se1", st.WorkoutSessionExerciseID)
+			st.SetNumber = 3
+		}).Once()
+
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		st, err := uc.AddSet(context.Background(), "u1", "s1", "se1", domain.SessionSetInput{ActualReps: &reps, ActualWeight: &weight})
+		require.NoError(t, err)
+		assert.Equal(t, 3, st.SetNumber)
+		assert.True(t, st.IsCompleted())
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestSessionUsecase_FinishSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1", StartedAt: time.Now().UTC().Add(-time.Hour)}, nil).Once()
+		repo.On("Finish", mock.Anything, "s1", "u1", mock.AnythingOfType("time.Time"), "felt strong", []domain.PersonalRecord{}).Return(nil).Once()
+		rollups := new(mocks.MockRollupRepository)
+		rollups.On("RefreshSession", mock.Anything, "u1", "s1").Return(nil).Once()
+
+		workoutRepo := new(mocks.MockWorkoutRepository)
+		uc := usecase.NewSessionUsecase(repo, workoutRepo, new(mocks.MockExerciseRepository), new(mocks.MockRecordRepository), rollups, usecase.NewWorkoutUsecase(workoutRepo), discardLogger)
+		s, records, err := uc.FinishSession(context.Background(), "u1", "s1", "felt strong")
+		require.NoError(t, err)
+		require.NotNil(t, s.CompletedAt)
+		assert.Equal(t, domain.SessionStatusCompleted, s.Status())
+		assert.Empty(t, records)
+		repo.AssertExpectations(t)
+		rollups.AssertExpectations(t)
+	})
+
+	t.Run("rollup failure invalidates rollups without failing the finish", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1", StartedAt: time.Now().UTC().Add(-time.Hour)}, nil).Once()
+		repo.On("Finish", mock.Anything, "s1", "u1", mock.AnythingOfType("time.Time"), "", []domain.PersonalRecord{}).Return(nil).Once()
+		rollups := new(mocks.MockRollupRepository)
+		rollups.On("RefreshSession", mock.Anything, "u1", "s1").Return(errors.New("connection reset")).Once()
+		rollups.On("Invalidate", mock.Anything, "u1").Return(nil).Once()
+
+		workoutRepo := new(mocks.MockWorkoutRepository)
+		uc := usecase.NewSessionUsecase(repo, workoutRepo, new(mocks.MockExerciseRepository), new(mocks.MockRecordRepository), rollups, usecase.NewWorkoutUsecase(workoutRepo), discardLogger)
+		s, _, err := uc.FinishSession(context.Background(), "u1", "s1", "")
+		require.NoError(t, err)
+		assert.Equal(t, domain.SessionStatusCompleted, s.Status())
+		repo.AssertExpectations(t)
+		rollups.AssertExpectations(t)
+	})
+
+	t.Run("records personal records", func(t *testing.T) {
+		reps := 5
+		weight := 100.0
+		done := time.Now().UTC()
+		session := &domain.WorkoutSession{
+			ID: "s1", UserID: "u1", StartedAt: time.Now().UTC().Add(-time.Hour),
+			Exercises: []domain.WorkoutSessionExercise{{
+				ID: "se1", ExerciseID: "e1", Sets: 1, Reps: 5, Weight: 100,
+				SetLogs: []domain.WorkoutSessionSet{{SetNumber: 1, ActualReps: &reps, ActualWeight: &weight, CompletedAt: &done}},
+			}},
+		}
+
+		repo := new(mocks.MockWorkoutSessionRepository)
+		records := new(mocks.MockRecordRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(session, nil).Once()
+		repo.On("Finish", mock.Anything, "s1", "u1", mock.AnythingOfType("time.Time"), "", mock.MatchedBy(func(prs []domain.PersonalRecord) bool {
+			return len(prs) == 3
+		})).Return(nil).Once()
+		records.On("GetCurrent", mock.Anything, "u1", []string{"e1"}).Return([]domain.PersonalRecord{
+			{ExerciseID: "e1", Type: domain.RecordMaxWeight, Value: 100, Weight: 100, Reps: 3},
+		}, nil).Once()
+		rollups := new(mocks.MockRollupRepository)
+		rollups.On("RefreshSession", mock.Anything, "u1", "s1").Return(nil).Once()
+
+		workoutRepo := new(mocks.MockWorkoutRepository)
+		uc := usecase.NewSessionUsecase(repo, workoutRepo, new(mocks.MockExerciseRepository), records, rollups, usecase.NewWorkoutUsecase(workoutRepo), discardLogger)
+		_, got, err := uc.FinishSession(context.Background(), "u1", "s1", "")
+		require.NoError(t, err)
+
+		types := make([]string, 0, len(got))
+		for _, pr := range got {
+			types = append(types, pr.Type)
+		}
+		assert.Equal(t, []string{domain.RecordMaxReps, domain.RecordOneRepMax, domain.RecordSessionVolume}, types)
+		repo.AssertExpectations(t)
+		records.AssertExpectations(t)
+	})
+
+	t.Run("already finished", func(t *testing.T) {
+		done := time.Now().UTC()
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1", CompletedAt: &done}, nil).Once()
+
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		_, _, err := uc.FinishSession(context.Background(), "u1", "s1", "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrConflict))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(nil, sql.ErrNoRows).Once()
+
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		_, _, err := uc.FinishSession(context.Background(), "u1", "s1", "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestSessionUsecase_GetSessions(t *testing.T) {
+	t.Parallel()
+
+	repo := new(mocks.MockWorkoutSessionRepository)
+	uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+
+	expected := domain.NewPaginatedResult([]domain.WorkoutSession{{ID: "s1"}}, 1, domain.NewPagination(1, 10))
+	repo.On("GetByUser", mock.Anything, "u1", mock.Anything, domain.WorkoutSessionFilter{Status: domain.SessionStatusCompleted}).Return(expected, nil).Once()
+
+	res, err := uc.GetSessions(context.Background(), "u1", domain.NewPagination(1, 10), domain.WorkoutSessionFilter{Status: domain.SessionStatusCompleted})
+	require.NoError(t, err)
+	assert.Len(t, res.Data, 1)
+	repo.AssertExpectations(t)
+
+	_, err = uc.GetSessions(context.Background(), "u1", domain.NewPagination(1, 10), domain.WorkoutSessionFilter{Status: "bogus"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+}
+
+func TestSessionUsecase_AddExercise(t *testing.T) {
+	t.Parallel()
+
+	finishedAt := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		sets        int
+		setupMock   func(s *mocks.MockWorkoutSessionRepository, e *mocks.MockExerciseRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			sets: 3,
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, e *mocks.MockExerciseRepository) {
+				s.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1"}, nil).Once()
+				e.On("GetByID", mock.Anything, "e1").Return(&domain.Exercise{ID: "e1"}, nil).Once()
+				s.On("AddExercise", mock.Anything, mock.AnythingOfType("*domain.WorkoutSessionExercise")).Return(nil).Run(func(args mock.Arguments) {
+					ex := args.Get(1).(*domain.WorkoutSessionExercise)
+					assert.Equal(t, "s1", ex.WorkoutSessionID)
+					assert.Len(t, ex.SetLogs, 3)
+				}).Once()
+			},
+		},
+		{
+			name:        "invalid sets",
+			sets:        0,
+			setupMock:   func(s *mocks.MockWorkoutSessionRepository, e *mocks.MockExerciseRepository) {},
+			expectedErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "unknown exercise",
+			sets: 3,
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, e *mocks.MockExerciseRepository) {
+				s.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1"}, nil).Once()
+				e.On("GetByID", mock.Anything, "e1").Return(nil, sql.ErrNoRows).Once()
+			},
+			expectedErr: domain.ErrNotFound,
+		},
+		{
+			name: "session finished",
+			sets: 3,
+			setupMock: func(s *mocks.MockWorkoutSessionRepository, e *mocks.MockExerciseRepository) {
+				s.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1", CompletedAt: &finishedAt}, nil).Once()
+			},
+			expectedErr: domain.ErrConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockWorkoutSessionRepository)
+			exerciseRepo := new(mocks.MockExerciseRepository)
+			tt.setupMock(repo, exerciseRepo)
+
+			uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), exerciseRepo)
+			_, err := uc.AddExercise(context.Background(), "u1", "s1", "e1", tt.sets, 10, 20)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			}
+			repo.AssertExpectations(t)
+			exerciseRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionUsecase_SaveAsPlan(t *testing.T) {
+	t.Parallel()
+
+	reps := func(v int) *int { return &v }
+	weight := func(v float64) *float64 { return &v }
+	done := time.Now().UTC()
+
+	finished := &domain.WorkoutSession{
+		ID:          "s1",
+		CompletedAt: &done,
+		Exercises: []domain.WorkoutSessionExercise{
+			{
+				ID: "se1", ExerciseID: "e1", Sets: 3, Reps: 10, Weight: 20,
+				SetLogs: []domain.WorkoutSessionSet{
+					{SetNumber: 1, ActualReps: reps(10), ActualWeight: weight(40), IsWarmup: true, CompletedAt: &done},
+					{SetNumber: 2, ActualReps: reps(6), ActualWeight: weight(80), CompletedAt: &done},
+					{SetNumber: 3, ActualReps: reps(8), ActualWeight: weight(75), CompletedAt: &done},
+				},
+			},
+			{ID: "se2", ExerciseID: "e2", Sets: 2, Reps: 12, Weight: 10},
+		},
+	}
+
+	t.Run("success uses logged sets", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		workoutRepo := new(mocks.MockWorkoutRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(finished, nil).Once()
+		workoutRepo.On("CreatePlan", mock.Anything, mock.AnythingOfType("*domain.WorkoutPlan"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			exercises := args.Get(2).([]domain.WorkoutPlanExercise)
+			require.Len(t, exercises, 2)
+			assert.Equal(t, domain.WorkoutPlanExercise{ExerciseID: "e1", Sets: 2, Reps: 6, Weight: 80, OrderIndex: 0}, exercises[0])
+			assert.Equal(t, domain.WorkoutPlanExercise{ExerciseID: "e2", Sets: 2, Reps: 12, Weight: 10, OrderIndex: 1}, exercises[1])
+			args.Get(1).(*domain.WorkoutPlan).ID = "p9"
+		}).Once()
+
+		uc := newSessionUsecase(repo, workoutRepo, new(mocks.MockExerciseRepository))
+		plan, err := uc.SaveAsPlan(context.Background(), "u1", "s1", "Freestyle", "")
+		require.NoError(t, err)
+		assert.Equal(t, "p9", plan.ID)
+		repo.AssertExpectations(t)
+		workoutRepo.AssertExpectations(t)
+	})
+
+	t.Run("session in progress", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutSessionRepository)
+		repo.On("GetByID", mock.Anything, "s1", "u1").Return(&domain.WorkoutSession{ID: "s1"}, nil).Once()
+
+		uc := newSessionUsecase(repo, new(mocks.MockWorkoutRepository), new(mocks.MockExerciseRepository))
+		_, err := uc.SaveAsPlan(context.Background(), "u1", "s1", "Freestyle", "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrConflict))
+		repo.AssertExpectations(t)
+	})
+}
@@ -110,3 +110,33 @@ func (u *UserUsecase) GetByID(ctx context.Context, id string) (*domain.User, err
 	user.PasswordHash = ""
 	return user, nil
 }
+
+func (u *UserUsecase) UpdatePreferences(ctx context.Context, id string, prefs domain.UserPreferencesUpdate) (*domain.User, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("update preferences: %w", domain.ErrInvalidInput)
+	}
+
+	if prefs.Timezone != nil {
+		loc, err := domain.LoadTimezone(*prefs.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("update preferences: %w", err)
+		}
+		name := loc.String()
+		prefs.Timezone = &name
+	}
+	if prefs.ReminderLeadMinutes != nil {
+		if *prefs.ReminderLeadMinutes < 0 || *prefs.ReminderLeadMinutes > domain.MaxReminderLeadMinutes {
+			return nil, fmt.Errorf("update preferences: %w", domain.ErrInvalidInput)
+		}
+	}
+
+	if err := u.repo.UpdatePreferences(ctx, id, prefs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("update preferences: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("update preferences: %w", err)
+	}
+
+	return u.GetByID(ctx, id)
+}
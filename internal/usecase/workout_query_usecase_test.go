@@ -47,6 +47,45 @@ func TestWorkoutUsecase_GetPlanByID(t *testing.T) {
 	})
 }
 
+func TestWorkoutUsecase_GetPlanDetail(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid input", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutRepository)
+		uc := usecase.NewWorkoutUsecase(repo)
+		_, err := uc.GetPlanDetail(context.Background(), "u1", " ")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrInvalidInput))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutRepository)
+		repo.On("GetPlanDetail", mock.Anything, "p1", "u1").Return(nil, nil).Once()
+		uc := usecase.NewWorkoutUsecase(repo)
+		_, err := uc.GetPlanDetail(context.Background(), "u1", "p1")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, domain.ErrNotFound))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(mocks.MockWorkoutRepository)
+		detail := &domain.WorkoutPlanDetail{
+			WorkoutPlan: domain.WorkoutPlan{ID: "p1"},
+			Exercises: []domain.WorkoutPlanExerciseDetail{
+				{WorkoutPlanExercise: domain.WorkoutPlanExercise{ExerciseID: "e1", Sets: 3, Reps: 8, Weight: 60}, ExerciseName: "Bench Press"},
+			},
+		}
+		repo.On("GetPlanDetail", mock.Anything, "p1", "u1").Return(detail, nil).Once()
+		uc := usecase.NewWorkoutUsecase(repo)
+		p, err := uc.GetPlanDetail(context.Background(), "u1", "p1")
+		require.NoError(t, err)
+		require.Len(t, p.Exercises, 1)
+		assert.Equal(t, "Bench Press", p.Exercises[0].ExerciseName)
+		repo.AssertExpectations(t)
+	})
+}
+
 func TestWorkoutUsecase_DeletePlan_InvalidInput(t *testing.T) {
 	t.Parallel()
 
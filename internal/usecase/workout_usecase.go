@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 
 	"workout-tracker/internal/domain"
@@ -26,32 +27,39 @@ func (u *WorkoutUsecase) CreatePlan(
 	name string,
 	notes string,
 	exercises []domain.WorkoutPlanExercise,
-) error {
+) (*domain.WorkoutPlan, error) {
 	userID = strings.TrimSpace(userID)
 	name = strings.TrimSpace(name)
 	notes = strings.TrimSpace(notes)
 
 	if userID == "" {
-		return fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+		return nil, fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
 	}
 	if name == "" {
-		return fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+		return nil, fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
 	}
 	if len(exercises) < 1 {
-		return fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+		return nil, fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
 	}
 
+	exercises = withSetShorthand(exercises)
 	for _, ex := range exercises {
 		if strings.TrimSpace(ex.ExerciseID) == "" {
-			return fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+			return nil, fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
 		}
 		if ex.Sets <= 0 {
-			return fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+			return nil, fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
 		}
 		if ex.Reps <= 0 {
-			return fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+			return nil, fmt.Errorf("create plan: %w", domain.ErrInvalidInput)
+		}
+		if err := domain.ValidateSetPrescriptions(ex); err != nil {
+			return nil, fmt.Errorf("create plan: %w", err)
 		}
 	}
+	if err := domain.ValidateExerciseGroups(exercises); err != nil {
+		return nil, fmt.Errorf("create plan: %w", err)
+	}
 
 	plan := &domain.WorkoutPlan{
 		UserID: userID,
@@ -60,10 +68,10 @@ func (u *WorkoutUsecase) CreatePlan(
 	}
 
 	if err := u.repo.CreatePlan(ctx, plan, exercises); err != nil {
-		return fmt.Errorf("create plan: %w", err)
+		return nil, fmt.Errorf("create plan: %w", err)
 	}
 
-	return nil
+	return plan, nil
 }
 
 func (u *WorkoutUsecase) UpdatePlan(
@@ -92,6 +100,7 @@ func (u *WorkoutUsecase) UpdatePlan(
 		return fmt.Errorf("update plan: %w", domain.ErrInvalidInput)
 	}
 
+	exercises = withSetShorthand(exercises)
 	for _, ex := range exercises {
 		if strings.TrimSpace(ex.ExerciseID) == "" {
 			return fmt.Errorf("update plan: %w", domain.ErrInvalidInput)
@@ -102,6 +111,12 @@ func (u *WorkoutUsecase) UpdatePlan(
 		if ex.Reps <= 0 {
 			return fmt.Errorf("update plan: %w", domain.ErrInvalidInput)
 		}
+		if err := domain.ValidateSetPrescriptions(ex); err != nil {
+			return fmt.Errorf("update plan: %w", err)
+		}
+	}
+	if err := domain.ValidateExerciseGroups(exercises); err != nil {
+		return fmt.Errorf("update plan: %w", err)
 	}
 
 	plan := &domain.WorkoutPlan{
@@ -156,6 +171,93 @@ func (u *WorkoutUsecase) GetPlanByID(ctx context.Context, userID string, planID
 	return plan, nil
 }
 
+func (u *WorkoutUsecase) GetPlanDetail(ctx context.Context, userID string, planID string) (*domain.WorkoutPlanDetail, error) {
+	userID = strings.TrimSpace(userID)
+	planID = strings.TrimSpace(planID)
+
+	if userID == "" {
+		return nil, fmt.Errorf("get plan detail: %w", domain.ErrInvalidInput)
+	}
+	if planID == "" {
+		return nil, fmt.Errorf("get plan detail: %w", domain.ErrInvalidInput)
+	}
+
+	plan, err := u.repo.GetPlanDetail(ctx, planID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get plan detail: %w", err)
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("get plan detail: %w", domain.ErrNotFound)
+	}
+
+	return plan, nil
+}
+
+// ClonePlan copies a plan and its exercises into a new plan for the same
+// user. An empty name becomes "<name> (copy)"; a weight scale multiplies
+// every prescribed weight and training max, rounded to two decimals.
+func (u *WorkoutUsecase) ClonePlan(ctx context.Context, userID string, planID string, name string, weightScale *float64) (*domain.WorkoutPlan, error) {
+	userID = strings.TrimSpace(userID)
+	planID = strings.TrimSpace(planID)
+	name = strings.TrimSpace(name)
+
+	if userID == "" || planID == "" {
+		return nil, fmt.Errorf("clone plan: %w", domain.ErrInvalidInput)
+	}
+	if weightScale != nil && (*weightScale <= 0 || math.IsInf(*weightScale, 0) || math.IsNaN(*weightScale)) {
+		return nil, fmt.Errorf("clone plan: %w", domain.ErrInvalidInput)
+	}
+
+	src, err := u.repo.GetPlanDetail(ctx, planID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("clone plan: %w", err)
+	}
+	if src == nil {
+		return nil, fmt.Errorf("clone plan: %w", domain.ErrNotFound)
+	}
+
+	if name == "" {
+		name = src.Name + " (copy)"
+	}
+
+	scale := func(w float64) float64 { return w }
+	if weightScale != nil {
+		factor := *weightScale
+		scale = func(w float64) float64 { return math.Round(w*factor*100) / 100 }
+	}
+
+	exercises := make([]domain.WorkoutPlanExercise, 0, len(src.Exercises))
+	for _, ex := range src.Exercises {
+		clone := domain.WorkoutPlanExercise{
+			ExerciseID: ex.ExerciseID,
+			Sets:       ex.Sets,
+			Reps:       ex.Reps,
+			Weight:     scale(ex.Weight),
+			OrderIndex: ex.OrderIndex,
+			Group:      ex.Group,
+		}
+		if ex.TrainingMax != nil {
+			tm := scale(*ex.TrainingMax)
+			clone.TrainingMax = &tm
+		}
+		for _, p := range ex.SetPrescriptions {
+			if p.Weight != nil {
+				w := scale(*p.Weight)
+				p.Weight = &w
+			}
+			clone.SetPrescriptions = append(clone.SetPrescriptions, p)
+		}
+		exercises = append(exercises, clone)
+	}
+
+	plan, err := u.CreatePlan(ctx, userID, name, src.Notes, exercises)
+	if err != nil {
+		return nil, fmt.Errorf("clone plan: %w", err)
+	}
+
+	return plan, nil
+}
+
 func (u *WorkoutUsecase) DeletePlan(ctx context.Context, userID string, planID string) error {
 	userID = strings.TrimSpace(userID)
 	planID = strings.TrimSpace(planID)
@@ -176,3 +278,119 @@ func (u *WorkoutUsecase) DeletePlan(ctx context.Context, userID string, planID s
 
 	return nil
 }
+
+func (u *WorkoutUsecase) ListPlanVersions(ctx context.Context, userID string, planID string, pagination domain.Pagination) (domain.PaginatedResult[domain.WorkoutPlanVersion], error) {
+	if err := u.checkPlanOwner(ctx, userID, planID); err != nil {
+		return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, fmt.Errorf("list plan versions: %w", err)
+	}
+
+	res, err := u.repo.GetPlanVersions(ctx, strings.TrimSpace(planID), pagination)
+	if err != nil {
+		return domain.PaginatedResult[domain.WorkoutPlanVersion]{}, fmt.Errorf("list plan versions: %w", err)
+	}
+	return res, nil
+}
+
+func (u *WorkoutUsecase) GetPlanVersion(ctx context.Context, userID string, planID string, version int) (*domain.WorkoutPlanVersion, error) {
+	if err := u.checkPlanOwner(ctx, userID, planID); err != nil {
+		return nil, fmt.Errorf("get plan version: %w", err)
+	}
+
+	v, err := u.planVersion(ctx, strings.TrimSpace(planID), version)
+	if err != nil {
+		return nil, fmt.Errorf("get plan version: %w", err)
+	}
+	return v, nil
+}
+
+// DiffPlanVersions reports what changed in the plan from version from to
+// version to.
+func (u *WorkoutUsecase) DiffPlanVersions(ctx context.Context, userID string, planID string, from int, to int) (domain.PlanVersionDiff, error) {
+	if err := u.checkPlanOwner(ctx, userID, planID); err != nil {
+		return domain.PlanVersionDiff{}, fmt.Errorf("diff plan versions: %w", err)
+	}
+
+	planID = strings.TrimSpace(planID)
+	a, err := u.planVersion(ctx, planID, from)
+	if err != nil {
+		return domain.PlanVersionDiff{}, fmt.Errorf("diff plan versions: %w", err)
+	}
+	b, err := u.planVersion(ctx, planID, to)
+	if err != nil {
+		return domain.PlanVersionDiff{}, fmt.Errorf("diff plan versions: %w", err)
+	}
+
+	return domain.DiffPlanVersions(*a, *b), nil
+}
+
+// RestorePlanVersion brings back an earlier version of the plan. The restore
+// is itself recorded as a new version, so it can be undone the same way.
+func (u *WorkoutUsecase) RestorePlanVersion(ctx context.Context, userID string, planID string, version int) (*domain.WorkoutPlanDetail, error) {
+	userID = strings.TrimSpace(userID)
+	planID = strings.TrimSpace(planID)
+
+	if userID == "" || planID == "" || version < 1 {
+		return nil, fmt.Errorf("restore plan version: %w", domain.ErrInvalidInput)
+	}
+
+	if err := u.repo.RestorePlanVersion(ctx, planID, userID, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("restore plan version: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("restore plan version: %w", err)
+	}
+
+	plan, err := u.repo.GetPlanDetail(ctx, planID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("restore plan version: %w", err)
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("restore plan version: %w", domain.ErrNotFound)
+	}
+
+	return plan, nil
+}
+
+func (u *WorkoutUsecase) checkPlanOwner(ctx context.Context, userID string, planID string) error {
+	userID = strings.TrimSpace(userID)
+	planID = strings.TrimSpace(planID)
+
+	if userID == "" || planID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	plan, err := u.repo.GetPlanByID(ctx, planID, userID)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (u *WorkoutUsecase) planVersion(ctx context.Context, planID string, version int) (*domain.WorkoutPlanVersion, error) {
+	if version < 1 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	v, err := u.repo.GetPlanVersion(ctx, planID, version)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, domain.ErrNotFound
+	}
+	return v, nil
+}
+
+// withSetShorthand returns a copy of exercises with the flat set fields of
+// each per-set prescription filled in.
+func withSetShorthand(exercises []domain.WorkoutPlanExercise) []domain.WorkoutPlanExercise {
+	out := make([]domain.WorkoutPlanExercise, len(exercises))
+	for i, ex := range exercises {
+		ex.ApplySetShorthand()
+		out[i] = ex
+	}
+	return out
+}
@@ -19,6 +19,24 @@ func TestWorkoutUsecase_CreatePlan(t *testing.T) {
 	t.Parallel()
 
 	validExercises := []domain.WorkoutPlanExercise{{ExerciseID: "e1", Sets: 3, Reps: 10}}
+	superset := &domain.ExerciseGroup{ID: "A", Type: domain.GroupSuperset, Rounds: 3, RestSeconds: 90}
+	supersetExercises := []domain.WorkoutPlanExercise{
+		{ExerciseID: "e1", Sets: 3, Reps: 10, OrderIndex: 0, Group: superset},
+		{ExerciseID: "e2", Sets: 3, Reps: 12, OrderIndex: 1, Group: superset},
+	}
+	tm := 100.0
+	warmupWeight, workPercent, topPercent := 40.0, 70.0, 85.0
+	prescribed := []domain.SetPrescription{
+		{Type: domain.SetTypeWarmup, Reps: 10, Weight: &warmupWeight},
+		{Type: domain.SetTypeWorking, Reps: 5, Percent: &workPercent},
+		{Type: domain.SetTypeAMRAP, Reps: 5, Percent: &topPercent},
+	}
+	prescribedExercises := []domain.WorkoutPlanExercise{
+		{ExerciseID: "e1", OrderIndex: 0, TrainingMax: &tm, SetPrescriptions: prescribed},
+	}
+	normalizedExercises := []domain.WorkoutPlanExercise{
+		{ExerciseID: "e1", Sets: 3, Reps: 5, Weight: 70, OrderIndex: 0, TrainingMax: &tm, SetPrescriptions: prescribed},
+	}
 
 	tests := []struct {
 		name        string
@@ -69,6 +87,40 @@ func TestWorkoutUsecase_CreatePlan(t *testing.T) {
 			setupMock:   func(m *mocks.MockWorkoutRepository) {},
 			expectedErr: domain.ErrInvalidInput,
 		},
+		{
+			name:      "superset",
+			userID:    "u1",
+			planName:  "Plan",
+			exercises: supersetExercises,
+			setupMock: func(m *mocks.MockWorkoutRepository) {
+				m.On("CreatePlan", mock.Anything, mock.AnythingOfType("*domain.WorkoutPlan"), supersetExercises).Return(nil).Once()
+			},
+		},
+		{
+			name:        "superset with one exercise",
+			userID:      "u1",
+			planName:    "Plan",
+			exercises:   []domain.WorkoutPlanExercise{{ExerciseID: "e1", Sets: 3, Reps: 10, Group: superset}},
+			setupMock:   func(m *mocks.MockWorkoutRepository) {},
+			expectedErr: domain.ErrInvalidInput,
+		},
+		{
+			name:      "set prescriptions fill flat fields",
+			userID:    "u1",
+			planName:  "Plan",
+			exercises: prescribedExercises,
+			setupMock: func(m *mocks.MockWorkoutRepository) {
+				m.On("CreatePlan", mock.Anything, mock.AnythingOfType("*domain.WorkoutPlan"), normalizedExercises).Return(nil).Once()
+			},
+		},
+		{
+			name:        "percent without training max",
+			userID:      "u1",
+			planName:    "Plan",
+			exercises:   []domain.WorkoutPlanExercise{{ExerciseID: "e1", SetPrescriptions: prescribed[1:]}},
+			setupMock:   func(m *mocks.MockWorkoutRepository) {},
+			expectedErr: domain.ErrInvalidInput,
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,7 +130,7 @@ func TestWorkoutUsecase_CreatePlan(t *testing.T) {
 			tt.setupMock(repo)
 
 			uc := usecase.NewWorkoutUsecase(repo)
-			err := uc.CreatePlan(context.Background(), tt.userID, tt.planName, "", tt.exercises)
+			_, err := uc.CreatePlan(context.Background(), tt.userID, tt.planName, "", tt.exercises)
 			if tt.expectedErr == nil {
 				require.NoError(t, err)
 			} else {
@@ -224,3 +276,82 @@ func TestWorkoutUsecase_GetPlans(t *testing.T) {
 	_, err = uc2.GetPlans(context.Background(), "u1", domain.NewPagination(1, 10), domain.WorkoutPlanFilter{})
 	require.Error(t, err)
 }
+
+func TestWorkoutUsecase_ClonePlan(t *testing.T) {
+	t.Parallel()
+
+	src := &domain.WorkoutPlanDetail{
+		WorkoutPlan: domain.WorkoutPlan{ID: "p1", UserID: "u1", Name: "Push A", Notes: "chest"},
+		Exercises: []domain.WorkoutPlanExerciseDetail{
+			{WorkoutPlanExercise: domain.WorkoutPlanExercise{ID: "x1", ExerciseID: "e1", Sets: 3, Reps: 8, Weight: 62.5, OrderIndex: 0}, ExerciseName: "Bench Press"},
+			{WorkoutPlanExercise: domain.WorkoutPlanExercise{ID: "x2", ExerciseID: "e2", Sets: 3, Reps: 12, OrderIndex: 1}, ExerciseName: "Dips"},
+		},
+	}
+	scale := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name          string
+		newName       string
+		weightScale   *float64
+		source        *domain.WorkoutPlanDetail
+		wantName      string
+		wantExercises []domain.WorkoutPlanExercise
+		expectedErr   error
+	}{
+		{
+			name:     "default name",
+			source:   src,
+			wantName: "Push A (copy)",
+			wantExercises: []domain.WorkoutPlanExercise{
+				{ExerciseID: "e1", Sets: 3, Reps: 8, Weight: 62.5, OrderIndex: 0},
+				{ExerciseID: "e2", Sets: 3, Reps: 12, OrderIndex: 1},
+			},
+		},
+		{
+			name:        "new name and weight scale",
+			newName:     " Push B ",
+			weightScale: scale(1.05),
+			source:      src,
+			wantName:    "Push B",
+			wantExercises: []domain.WorkoutPlanExercise{
+				{ExerciseID: "e1", Sets: 3, Reps: 8, Weight: 65.63, OrderIndex: 0},
+				{ExerciseID: "e2", Sets: 3, Reps: 12, OrderIndex: 1},
+			},
+		},
+		{name: "plan not found", expectedErr: domain.ErrNotFound},
+		{name: "invalid scale", weightScale: scale(0), expectedErr: domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(mocks.MockWorkoutRepository)
+			if !errors.Is(tt.expectedErr, domain.ErrInvalidInput) {
+				if tt.source != nil {
+					repo.On("GetPlanDetail", mock.Anything, "p1", "u1").Return(tt.source, nil).Once()
+				} else {
+					repo.On("GetPlanDetail", mock.Anything, "p1", "u1").Return(nil, nil).Once()
+				}
+			}
+			if tt.expectedErr == nil {
+				repo.On("CreatePlan", mock.Anything, mock.MatchedBy(func(p *domain.WorkoutPlan) bool {
+					return p.UserID == "u1" && p.Name == tt.wantName && p.Notes == "chest"
+				}), tt.wantExercises).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.WorkoutPlan).ID = "p2"
+				}).Return(nil).Once()
+			}
+
+			plan, err := usecase.NewWorkoutUsecase(repo).ClonePlan(context.Background(), "u1", "p1", tt.newName, tt.weightScale)
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "p2", plan.ID)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}